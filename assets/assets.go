@@ -0,0 +1,9 @@
+// Package assets bundles the game's data files into the binary
+package assets
+
+import "embed"
+
+// Files holds the bundled word lists and keyboard layouts
+//
+//go:embed *.txt layouts/*.txt
+var Files embed.FS
@@ -3,21 +3,154 @@ package main
 import (
 	"ascii-type/internal/client"
 	"ascii-type/internal/core"
+	"flag"
+	"fmt"
 	"log"
+	"os"
+	"path/filepath"
+	"time"
 )
 
-const dummyGame = false // Set to true to use DummyGame for testing
+const profileDir = "profiles" // Profiles, their scores and replays are kept here
 
 func main() {
+	configFile := flag.String("config", "", "config file (default <user config dir>/ascii-type/config.json)")
+	ghostFile := flag.String("ghost", "", "replay file of a run to race against")
+	mode := flag.String("mode", "", "game mode ID, e.g. survival or sprint60")
+	pack := flag.String("pack", "", "word pack ID")
+	difficulty := flag.String("difficulty", "", "difficulty preset: easy, normal, hard, insane or custom")
+	speedStart := flag.Float64("speed-start", 0, "custom: starting scroll speed in pixels per second")
+	speedFactor := flag.Float64("speed-factor", 0, "custom: scroll speed multiplier at every ramp")
+	speedInterval := flag.Int("speed-interval", 0, "custom: words between speed ramps")
+	speedMax := flag.Float64("speed-max", 0, "custom: highest scroll speed, 0 for no limit")
+	wordMin := flag.Int("word-min", 0, "custom: shortest word")
+	wordMax := flag.Int("word-max", 0, "custom: longest word, 0 for no limit")
+	spacing := flag.Int("spacing", 0, "custom: rows between platforms")
+	theme := flag.String("theme", "", "color theme: classic, mono or light")
+	errorPolicy := flag.String("errors", "", "what wrong keys do: stop, allow or skip")
+	accents := flag.String("accents", "", "how accented letters match: exact or ignore")
+	locale := flag.String("locale", "", "language of the texts on screen: en or de")
+	profile := flag.String("profile", "", "profile to play as")
+	logPath := flag.String("log", "", "log file")
+	logLevel := flag.String("log-level", "", "log level: off, info or debug")
+	clientName := flag.String("client", "", "client: terminal, or dummy to test the terminal client")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "Usage: game [options]")
+		fmt.Fprintln(flag.CommandLine.Output(), "       game replay [-speed 1|2|4] <file>")
+		fmt.Fprintln(flag.CommandLine.Output(), "       game verify [-scores <highscores.json>] [replay files...]")
+		fmt.Fprintln(flag.CommandLine.Output(), "       game stats export [-format csv|json] [-out <path>] [filters]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	switch flag.Arg(0) {
+	case "replay":
+		if err := runReplay(flag.Args()[1:]); err != nil {
+			log.Fatalf("Replay error: %v", err)
+		}
+		return
+	case "verify":
+		if !runVerify(flag.Args()[1:]) {
+			os.Exit(1)
+		}
+		return
+	case "stats":
+		if err := runStats(flag.Args()[1:]); err != nil {
+			log.Fatalf("Stats error: %v", err)
+		}
+		return
+	}
+
+	config, err := loadConfig(*configFile)
+	if err != nil {
+		configError(err)
+	}
+	// Flags given on the command line win over the config file. Tuning a
+	// custom value plays the Custom preset unless another one is asked for.
+	custom, presetFlag := config.Custom, false
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "mode":
+			config.Mode = *mode
+		case "pack":
+			config.Pack = *pack
+		case "difficulty":
+			config.Difficulty = *difficulty
+			presetFlag = true
+		case "speed-start":
+			config.Custom.Speed.Start = *speedStart
+		case "speed-factor":
+			config.Custom.Speed.Factor = *speedFactor
+		case "speed-interval":
+			config.Custom.Speed.Interval = *speedInterval
+		case "speed-max":
+			config.Custom.Speed.Max = *speedMax
+		case "word-min":
+			config.Custom.MinWord = *wordMin
+		case "word-max":
+			config.Custom.MaxWord = *wordMax
+		case "spacing":
+			config.Custom.Spacing = *spacing
+		case "theme":
+			config.Theme = *theme
+		case "errors":
+			config.Errors = *errorPolicy
+		case "accents":
+			config.Accents = *accents
+		case "locale":
+			config.Locale = *locale
+		case "profile":
+			config.Profile = *profile
+		case "log":
+			config.LogPath = *logPath
+		case "log-level":
+			config.LogLevel = *logLevel
+		case "client":
+			config.Client = *clientName
+		}
+	})
+	if config.Custom != custom && !presetFlag {
+		config.Difficulty = "custom"
+	}
+	// Load the layouts first, a word pack drilling on one depends on them
+	if dir, err := core.LayoutDir(); err == nil {
+		if err := core.LoadLayouts(dir); err != nil {
+			configError(err)
+		}
+	}
+	if err := config.Validate(); err != nil {
+		configError(err)
+	}
+
 	var game core.GameInterface
-	var err error
-	if dummyGame {
+	if config.Client == core.ClientDummy {
 		game = core.NewDummyGame()
 	} else {
-		game, err = core.NewGame("game_log.txt")
+		g, err := core.NewGameFromConfig(config)
 		if err != nil {
 			log.Fatalf("Failed to create game: %v", err)
 		}
+		profiles, err := core.LoadProfiles(profileDir)
+		if err != nil {
+			log.Fatalf("Failed to load profiles: %v", err)
+		}
+		if config.Profile != "" {
+			if err := profiles.Select(config.Profile); err != nil {
+				configError(err)
+			}
+		}
+		if err := g.SetProfiles(profiles); err != nil {
+			log.Fatalf("Failed to open profile: %v", err)
+		}
+		if *ghostFile != "" {
+			ghost, err := core.LoadReplay(*ghostFile)
+			if err != nil {
+				log.Fatalf("Failed to load ghost: %v", err)
+			}
+			if err := g.SetGhost(ghost, filepath.Base(*ghostFile)); err != nil {
+				log.Fatalf("Failed to set ghost: %v", err)
+			}
+		}
+		game = g
 	}
 
 	// Create terminal client
@@ -28,3 +161,194 @@ func main() {
 		log.Fatalf("Game error: %v", err)
 	}
 }
+
+// loadConfig reads the config file at path, or the one in the user's config
+// directory if path is empty
+func loadConfig(path string) (*core.Config, error) {
+	if path == "" {
+		var err error
+		if path, err = core.ConfigPath(); err != nil {
+			return nil, err
+		}
+	}
+	return core.LoadConfig(path)
+}
+
+// configError reports a bad configuration and exits
+func configError(err error) {
+	fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
+	fmt.Fprintln(os.Stderr, "Run with -h for the available options.")
+	os.Exit(2)
+}
+
+// runReplay plays a recorded run: game replay [-speed 1|2|4] <file>
+func runReplay(args []string) error {
+	flags := flag.NewFlagSet("replay", flag.ExitOnError)
+	speed := flags.Int("speed", 1, "playback speed: 1, 2 or 4")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: game replay [-speed 1|2|4] <file>")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	replay, err := core.LoadReplay(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	game, err := core.NewPlaybackGame("replay_log.txt")
+	if err != nil {
+		return err
+	}
+	player, err := core.NewReplayPlayer(game, replay)
+	if err != nil {
+		return err
+	}
+	player.SetSpeed(*speed)
+
+	return client.NewTerminalClient(player).Run()
+}
+
+// runVerify re-simulates replays and the replays behind high scores:
+// game verify [-scores <highscores.json>] [replay files...]
+// It reports whether everything checked out.
+func runVerify(args []string) bool {
+	flags := flag.NewFlagSet("verify", flag.ExitOnError)
+	scores := flags.String("scores", "", "high score file whose entries are checked against their replays")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: game verify [-scores <highscores.json>] [replay files...]")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() == 0 && *scores == "" {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	ok := true
+	report := func(name string, v *core.Verification, err error) {
+		switch {
+		case err != nil:
+			fmt.Printf("%s: ERROR %v\n", name, err)
+			ok = false
+		case v.OK():
+			fmt.Printf("%s: OK (%d points, %d words, %.1f WPM)\n", name, v.Replayed.Score, v.Replayed.WordsTyped, v.Replayed.WPM)
+		default:
+			fmt.Printf("%s: FAILED\n", name)
+			for _, issue := range v.Issues {
+				fmt.Printf("  - %s\n", issue)
+			}
+			ok = false
+		}
+	}
+
+	for _, path := range flags.Args() {
+		replay, err := core.LoadReplay(path)
+		if err != nil {
+			report(path, nil, err)
+			continue
+		}
+		v, err := core.VerifyReplay(replay)
+		report(path, v, err)
+	}
+
+	if *scores != "" {
+		table, err := core.LoadHighScores(*scores)
+		if err != nil {
+			report(*scores, nil, err)
+			return false
+		}
+		for _, mode := range core.Modes() {
+			for i, entry := range table.Top(mode) {
+				v, err := core.VerifyHighScore(mode.ID(), entry)
+				report(fmt.Sprintf("%s #%d", mode.Name(), i+1), v, err)
+			}
+		}
+	}
+	return ok
+}
+
+// runStats exports statistics for analysis elsewhere:
+// game stats export [-format csv|json] [-out <path>] [-profile <name>] [-mode <id>] [-from <date>] [-to <date>]
+func runStats(args []string) error {
+	flags := flag.NewFlagSet("stats export", flag.ExitOnError)
+	format := flags.String("format", "csv", "output format: csv or json")
+	out := flags.String("out", "", "directory for csv files (default stats-export), file for json (default stdout)")
+	profile := flags.String("profile", "", "only export this profile (default every profile)")
+	mode := flags.String("mode", "", "only export runs of this mode ID, e.g. sprint60")
+	from := flags.String("from", "", "only export runs on or after this date, YYYY-MM-DD")
+	to := flags.String("to", "", "only export runs on or before this date, YYYY-MM-DD")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: game stats export [-format csv|json] [-out <path>] [-profile <name>] [-mode <id>] [-from <date>] [-to <date>]")
+		flags.PrintDefaults()
+	}
+	if len(args) == 0 || args[0] != "export" {
+		flags.Usage()
+		os.Exit(2)
+	}
+	flags.Parse(args[1:])
+
+	var filter core.ExportFilter
+	if *profile != "" {
+		filter.Profiles = []string{*profile}
+	}
+	if *mode != "" {
+		if _, ok := core.ModeByID(*mode); !ok {
+			return fmt.Errorf("unknown mode %q", *mode)
+		}
+		filter.Mode = *mode
+	}
+	var err error
+	if *from != "" {
+		if filter.From, err = time.ParseInLocation("2006-01-02", *from, time.Local); err != nil {
+			return fmt.Errorf("invalid -from date: %v", err)
+		}
+	}
+	if *to != "" {
+		if filter.To, err = time.ParseInLocation("2006-01-02", *to, time.Local); err != nil {
+			return fmt.Errorf("invalid -to date: %v", err)
+		}
+		filter.To = filter.To.AddDate(0, 0, 1) // The whole last day is included
+	}
+
+	profiles, err := core.LoadProfiles(profileDir)
+	if err != nil {
+		return err
+	}
+	export, err := core.CollectStats(profiles, filter, time.Now())
+	if err != nil {
+		return err
+	}
+
+	switch *format {
+	case "csv":
+		dir := *out
+		if dir == "" {
+			dir = "stats-export"
+		}
+		if err := export.WriteCSV(dir); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Exported %d sessions, %d keys and %d high scores to %s\n",
+			len(export.Sessions), len(export.Keys), len(export.HighScores), dir)
+		return nil
+	case "json":
+		if *out == "" {
+			return export.WriteJSON(os.Stdout)
+		}
+		file, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		if err := export.WriteJSON(file); err != nil {
+			file.Close()
+			return err
+		}
+		return file.Close()
+	default:
+		return fmt.Errorf("unknown format %q, expected csv or json", *format)
+	}
+}
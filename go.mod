@@ -2,6 +2,7 @@ module ascii-type
 
 go 1.21
 
-require github.com/nsf/termbox-go v1.1.1
-
-require github.com/mattn/go-runewidth v0.0.9 // indirect
+require (
+	github.com/mattn/go-runewidth v0.0.9
+	github.com/nsf/termbox-go v1.1.1
+)
@@ -2,16 +2,30 @@ package client
 
 import (
 	"ascii-type/internal/core"
+	"fmt"
 	"time"
 
+	"github.com/mattn/go-runewidth"
 	"github.com/nsf/termbox-go"
 )
 
+// arrowKeys maps the arrow keys to the keys the game reads them as
+var arrowKeys = map[termbox.Key]rune{
+	termbox.KeyArrowUp:    core.KeyUp,
+	termbox.KeyArrowDown:  core.KeyDown,
+	termbox.KeyArrowLeft:  core.KeyLeft,
+	termbox.KeyArrowRight: core.KeyRight,
+}
+
 // TerminalClient handles terminal I/O and display
 type TerminalClient struct {
 	game   core.GameInterface
 	width  int
 	height int
+
+	// backspaceDEL is set once Backspace was seen sending DEL, which makes
+	// Ctrl+H the Ctrl+Backspace of the terminal
+	backspaceDEL bool
 }
 
 // NewTerminalClient creates a new terminal client
@@ -73,14 +87,29 @@ func (tc *TerminalClient) handleEvent(event termbox.Event) bool {
 	switch event.Type {
 	case termbox.EventKey:
 		// Handle special keys
-		if event.Key == termbox.KeyEsc {
+		if event.Key == termbox.KeyCtrlC {
+			return false // Exit
+		} else if event.Key == termbox.KeyEsc {
 			tc.game.ProcessInput(27) // ESC
 		} else if event.Key == termbox.KeySpace {
 			tc.game.ProcessInput(' ')
-		} else if event.Key == termbox.KeyBackspace || event.Key == termbox.KeyBackspace2 {
-			tc.game.ProcessInput(8) // Backspace
-		} else if event.Key == termbox.KeyCtrlC {
-			return false // Exit
+		} else if event.Key == termbox.KeyBackspace2 {
+			tc.backspaceDEL = true
+			tc.game.ProcessInput(core.KeyBackspace)
+		} else if event.Key == termbox.KeyBackspace {
+			// Some terminals send Ctrl+H for Backspace itself; it only means
+			// Ctrl+Backspace where Backspace sends DEL
+			if tc.backspaceDEL {
+				tc.game.ProcessInput(core.KeyCtrlBackspace)
+			} else {
+				tc.game.ProcessInput(core.KeyBackspace)
+			}
+		} else if event.Key == termbox.KeyEnter {
+			tc.game.ProcessInput(core.KeyEnter)
+		} else if key, ok := arrowKeys[event.Key]; ok {
+			tc.game.ProcessInput(key)
+		} else if event.Ch == 0 && event.Key < termbox.KeySpace {
+			tc.game.ProcessInput(rune(event.Key)) // Tab and Ctrl combinations, for key bindings
 		} else if event.Ch != 0 {
 			// Regular character
 			tc.game.ProcessInput(event.Ch)
@@ -138,8 +167,9 @@ func (tc *TerminalClient) drawFrame(frame string) {
 				currentFg, currentBg = tc.parseColor(escapeSeq)
 				escapeSeq = ""
 			} else if ch == 'H' {
-				// Cursor position - extract coordinates if needed
+				// Cursor position - move to the 1-based row;col, bare "H" means home
 				inEscape = false
+				x, y = parseCursorPosition(escapeSeq)
 				escapeSeq = ""
 			} else if ch == 'J' {
 				// Clear screen command
@@ -155,14 +185,25 @@ func (tc *TerminalClient) drawFrame(frame string) {
 		} else if ch == '\r' {
 			x = 0
 		} else {
-			if x < tc.width && y < tc.height {
+			// Wide characters take two cells, combining marks none
+			width := runewidth.RuneWidth(ch)
+			if x < tc.width && y < tc.height && width > 0 {
 				termbox.SetCell(x, y, ch, currentFg, currentBg)
 			}
-			x++
+			x += width
 		}
 	}
 }
 
+// parseCursorPosition extracts the zero-based x, y from an ANSI "\033[row;colH" sequence
+func parseCursorPosition(escapeSeq string) (int, int) {
+	var row, col int
+	if _, err := fmt.Sscanf(escapeSeq, "\033[%d;%dH", &row, &col); err != nil {
+		return 0, 0
+	}
+	return col - 1, row - 1
+}
+
 // parseColor converts ANSI color codes to termbox colors
 func (tc *TerminalClient) parseColor(escapeSeq string) (termbox.Attribute, termbox.Attribute) {
 	fg := termbox.ColorDefault
@@ -189,6 +230,8 @@ func (tc *TerminalClient) parseColor(escapeSeq string) (termbox.Attribute, termb
 		fg = termbox.ColorWhite
 	case "\033[1m": // Bold (bright)
 		fg = fg | termbox.AttrBold
+	case "\033[2m": // Dim, e.g. the ghost
+		fg = fg | termbox.AttrDim
 	}
 
 	return fg, bg
@@ -0,0 +1,254 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// More keys without a character, see KeyEnter
+const (
+	KeyBackspace = 8
+	KeyTab       = 9
+	KeyCtrlW     = 23
+	KeyDelete    = 127
+
+	KeyCtrlBackspace = KeyRight + 1 // what terminals send as Ctrl+H
+)
+
+// Action is something a key can be bound to
+type Action string
+
+// Actions that can be remapped
+const (
+	ActionPause      Action = "pause"
+	ActionResume     Action = "resume"
+	ActionQuit       Action = "quit"
+	ActionStart      Action = "start"
+	ActionRestart    Action = "restart"
+	ActionDeleteChar Action = "delete_char"
+	ActionDeleteWord Action = "delete_word"
+	ActionSkipWord   Action = "skip_word"
+)
+
+// actionInfo describes an action: its name on screen, its default keys and
+// the states it is used in. Actions of the same state can't share a key.
+type actionInfo struct {
+	Action   Action
+	Name     string
+	Defaults []rune
+	States   []GameState
+}
+
+// actions holds every action in settings order
+var actions = []actionInfo{
+	{ActionPause, "Pause", []rune{KeyEscape}, []GameState{StatePlaying}},
+	{ActionResume, "Resume", []rune{KeyEscape}, []GameState{StatePaused}},
+	{ActionQuit, "Quit", []rune{'q'}, []GameState{StateMenu, StatePaused, StateGameOver}},
+	{ActionStart, "Start", []rune{' '}, []GameState{StateMenu, StateGameOver}},
+	{ActionRestart, "Retry course", []rune{'r'}, []GameState{StateGameOver}},
+	{ActionDeleteChar, "Delete character", []rune{KeyBackspace}, []GameState{StatePlaying}},
+	{ActionDeleteWord, "Delete word", []rune{KeyCtrlW, KeyCtrlBackspace}, []GameState{StatePlaying}},
+	{ActionSkipWord, "Skip word", []rune{KeyTab}, []GameState{StatePlaying}},
+}
+
+// reservedKeys are the fixed keys of a state, which actions can't take over
+var reservedKeys = map[GameState]string{
+	StateMenu:     "ygcrhskeuompdl\r" + string([]rune{KeyUp, KeyDown, KeyLeft, KeyRight, KeyEscape}),
+	StatePaused:   "s\r",
+	StateGameOver: "tm",
+}
+
+// Bindings maps every action to the keys that trigger it. Letters are
+// stored lower case and match either case.
+type Bindings map[Action][]rune
+
+// DefaultBindings returns the bindings used unless the config remaps them
+func DefaultBindings() Bindings {
+	b := Bindings{}
+	for _, info := range actions {
+		b[info.Action] = info.Defaults
+	}
+	return b
+}
+
+// Is reports whether key triggers action
+func (b Bindings) Is(action Action, key rune) bool {
+	key = unicode.ToLower(key)
+	for _, bound := range b[action] {
+		if bound == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Key returns the first key of action, the one shown on screen
+func (b Bindings) Key(action Action) rune {
+	if keys := b[action]; len(keys) > 0 {
+		return keys[0]
+	}
+	return 0
+}
+
+// Name returns how the keys of action are shown, e.g. "ESC/CTRL+P"
+func (b Bindings) Name(action Action) string {
+	names := make([]string, len(b[action]))
+	for i, key := range b[action] {
+		names[i] = keyName(key)
+	}
+	return strings.Join(names, "/")
+}
+
+// With returns a copy of the bindings with action bound to keys
+func (b Bindings) With(action Action, keys ...rune) Bindings {
+	copied := Bindings{}
+	for a, k := range b {
+		copied[a] = k
+	}
+	copied[action] = keys
+	return copied
+}
+
+// Validate checks that every action has a key, that actions used while
+// playing don't take keys that are typed and that no key does two things
+func (b Bindings) Validate() error {
+	for _, info := range actions {
+		keys := b[info.Action]
+		if len(keys) == 0 {
+			return fmt.Errorf("%w: %s has no key", ErrInvalidConfig, info.Action)
+		}
+		for _, key := range keys {
+			for _, state := range info.States {
+				if state == StatePlaying && isTypable(key) {
+					return fmt.Errorf("%w: %s can't use %s, it is typed in words", ErrInvalidConfig, info.Action, keyName(key))
+				}
+				if strings.ContainsRune(reservedKeys[state], key) {
+					return fmt.Errorf("%w: %s can't use %s, it is already taken", ErrInvalidConfig, info.Action, keyName(key))
+				}
+				for _, other := range actions {
+					if other.Action != info.Action && other.usedIn(state) && b.Is(other.Action, key) {
+						return fmt.Errorf("%w: %s is bound to both %s and %s", ErrInvalidConfig, keyName(key), info.Action, other.Action)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Name returns how the action is shown on screen
+func (a Action) Name() string {
+	for _, info := range actions {
+		if info.Action == a {
+			return info.Name
+		}
+	}
+	return string(a)
+}
+
+// usedIn reports whether the action is used in state
+func (info actionInfo) usedIn(state GameState) bool {
+	for _, s := range info.States {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseBindings overlays remapped actions, given as key names, on the
+// default bindings
+func ParseBindings(names map[string][]string) (Bindings, error) {
+	b := DefaultBindings()
+	for name, keyNames := range names {
+		action := Action(name)
+		if _, ok := b[action]; !ok {
+			var ids []string
+			for _, info := range actions {
+				ids = append(ids, string(info.Action))
+			}
+			return nil, invalidChoice("action", name, ids)
+		}
+		keys := make([]rune, len(keyNames))
+		for i, keyName := range keyNames {
+			key, err := ParseKey(keyName)
+			if err != nil {
+				return nil, err
+			}
+			keys[i] = key
+		}
+		b[action] = keys
+	}
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Names returns the key names of the actions that differ from the defaults,
+// as written to the config file
+func (b Bindings) Names() map[string][]string {
+	names := map[string][]string{}
+	for _, info := range actions {
+		keys := b[info.Action]
+		if string(keys) == string(info.Defaults) {
+			continue
+		}
+		for _, key := range keys {
+			names[string(info.Action)] = append(names[string(info.Action)], strings.ToLower(keyName(key)))
+		}
+	}
+	return names
+}
+
+// namedKeys are the keys written by name rather than as their character
+var namedKeys = map[string]rune{
+	"SPACE":          ' ',
+	"ENTER":          KeyEnter,
+	"ESC":            KeyEscape,
+	"TAB":            KeyTab,
+	"BACKSPACE":      KeyBackspace,
+	"CTRL+BACKSPACE": KeyCtrlBackspace,
+	"DELETE":         KeyDelete,
+	"UP":             KeyUp,
+	"DOWN":           KeyDown,
+	"LEFT":           KeyLeft,
+	"RIGHT":          KeyRight,
+}
+
+// keyName returns how a key is written on screen and in the config file
+func keyName(key rune) string {
+	for name, named := range namedKeys {
+		if named == key {
+			return name
+		}
+	}
+	if key >= 1 && key <= 26 {
+		return "CTRL+" + string('A'+key-1)
+	}
+	return strings.ToUpper(string(key))
+}
+
+// ParseKey reads a key name such as "esc", "ctrl+p", "tab" or "`"
+func ParseKey(name string) (rune, error) {
+	upper := strings.ToUpper(name)
+	if key, ok := namedKeys[upper]; ok {
+		return key, nil
+	}
+	if letter, ok := strings.CutPrefix(upper, "CTRL+"); ok && len(letter) == 1 && letter[0] >= 'A' && letter[0] <= 'Z' {
+		return rune(letter[0]-'A') + 1, nil
+	}
+	if runes := []rune(name); len(runes) == 1 && unicode.IsPrint(runes[0]) {
+		return unicode.ToLower(runes[0]), nil
+	}
+	return 0, fmt.Errorf("%w: unknown key %q, use a character, a name like esc, tab or space, or ctrl+<letter>", ErrInvalidConfig, name)
+}
+
+// SetBindings remaps the actions and updates the menu shortcuts
+func (g *Game) SetBindings(b Bindings) {
+	g.Bindings = b
+	selected := g.mainMenu.Selected
+	g.mainMenu = g.newMainMenu()
+	g.mainMenu.Selected = selected
+}
@@ -0,0 +1,177 @@
+package core
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseKey(t *testing.T) {
+	tests := map[string]rune{
+		"esc": KeyEscape, "Tab": KeyTab, "space": ' ', "ctrl+p": 16, "CTRL+W": KeyCtrlW, "`": '`', "Q": 'q',
+	}
+	for name, want := range tests {
+		key, err := ParseKey(name)
+		if err != nil || key != want {
+			t.Errorf("ParseKey(%q) = %d, %v; want %d", name, key, err, want)
+		}
+		if back, _ := ParseKey(keyName(key)); back != key {
+			t.Errorf("Expected %q to survive a round trip through its name %q", name, keyName(key))
+		}
+	}
+	for _, name := range []string{"", "ctrl+", "ctrl+1", "escape"} {
+		if _, err := ParseKey(name); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("ParseKey(%q): expected an error, got %v", name, err)
+		}
+	}
+}
+
+func TestParseBindings(t *testing.T) {
+	b, err := ParseBindings(map[string][]string{"pause": {"ctrl+p", "enter"}, "resume": {"ctrl+p"}})
+	if err != nil {
+		t.Fatalf("ParseBindings() error: %v", err)
+	}
+	if !b.Is(ActionPause, KeyEnter) || b.Is(ActionPause, KeyEscape) || !b.Is(ActionQuit, 'Q') {
+		t.Errorf("Expected the remapped pause keys on top of the defaults, got %v", b)
+	}
+	if b.Name(ActionPause) != "CTRL+P/ENTER" {
+		t.Errorf("Expected the keys to be shown as CTRL+P/ENTER, got %s", b.Name(ActionPause))
+	}
+	names := b.Names()
+	if len(names) != 2 || strings.Join(names["pause"], " ") != "ctrl+p enter" {
+		t.Errorf("Expected only the remapped actions to be written, got %v", names)
+	}
+
+	tests := []struct {
+		names map[string][]string
+		want  string
+	}{
+		{map[string][]string{"jump": {"j"}}, `unknown action "jump"`},
+		{map[string][]string{"pause": {"p"}}, "typed in words"},
+		{map[string][]string{"skip_word": {"`"}}, "typed in words"},
+		{map[string][]string{"delete_word": {"é"}}, "typed in words"},
+		{map[string][]string{"skip_word": {"backspace"}}, "bound to both"},
+		{map[string][]string{"start": {"y"}}, "already taken"},
+		{map[string][]string{"quit": {}}, "has no key"},
+	}
+	for _, tt := range tests {
+		if _, err := ParseBindings(tt.names); !errors.Is(err, ErrInvalidConfig) || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%v: expected an error containing %q, got %v", tt.names, tt.want, err)
+		}
+	}
+}
+
+func TestRemappedKeys(t *testing.T) {
+	game := newPlayingGame(t)
+	b, _ := ParseBindings(map[string][]string{"pause": {"ctrl+p"}, "delete_word": {"ctrl+u"}})
+	game.SetBindings(b)
+
+	game.ProcessInput(KeyEscape)
+	if game.State != StatePlaying {
+		t.Fatal("Expected ESC to no longer pause")
+	}
+	word := game.Platforms[game.Player.Platform].Word
+	game.ProcessInput(rune(word[0]))
+	game.ProcessInput(rune(word[1]))
+	game.ProcessInput(21) // Ctrl+U
+	if typed := game.Platforms[game.Player.Platform].Typed; typed != "" {
+		t.Errorf("Expected the word to be cleared, got %q", typed)
+	}
+
+	game.ProcessInput(rune(word[0]))
+	game.Combo = 3
+	game.ProcessInput(KeyTab)
+	if p := game.Platforms[game.Player.Platform]; p.Typed != "" || game.Combo != 0 || game.Mistakes != 0 {
+		t.Errorf("Expected Tab to swap the word and break the combo, got %+v with combo %d", p, game.Combo)
+	}
+
+	game.ProcessInput(16) // Ctrl+P
+	if game.State != StatePaused {
+		t.Fatal("Expected the remapped key to pause")
+	}
+	if !strings.Contains(game.Render(), "Press ESC to resume") {
+		t.Error("Expected the pause screen to show the resume key")
+	}
+}
+
+func TestReplayKeepsBindings(t *testing.T) {
+	game := newPlayingGame(t)
+	game.State = StateMenu
+	clock := NewManualClock(time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC))
+	game.Clock = clock
+	game.ApplySettings(RunSettings{Mode: "zen", Pack: "classic", Difficulty: DifficultyEasy, Seed: 7})
+	b, _ := ParseBindings(map[string][]string{"pause": {"ctrl+p"}, "resume": {"ctrl+p"}})
+	game.SetBindings(b)
+	game.ProcessInput(' ')
+	for i := 0; i < 2; i++ {
+		for _, ch := range game.Platforms[game.Player.Platform].Word {
+			clock.Advance(200 * time.Millisecond)
+			for f := 0; f < 12; f++ {
+				game.Render()
+			}
+			game.ProcessInput(ch)
+		}
+	}
+	for f := 0; f < 60; f++ {
+		clock.Advance(time.Second / 60)
+		game.Render()
+	}
+	game.ProcessInput(16) // Ctrl+P
+	clock.Advance(time.Second)
+	game.ProcessInput(KeyEnter)
+
+	if len(game.LastReplay.Bindings) != 2 {
+		t.Fatalf("Expected the replay to record the remapped keys, got %v", game.LastReplay.Bindings)
+	}
+	v, err := VerifyReplay(game.LastReplay)
+	if err != nil {
+		t.Fatalf("VerifyReplay() error: %v", err)
+	}
+	if !v.OK() {
+		t.Errorf("Expected the run to replay with its own bindings, got %v", v.Issues)
+	}
+}
+
+func TestSettingsRebindKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), configFileName)
+	config, _ := LoadConfig(path)
+	config.LogLevel = "off"
+	game, err := NewGameFromConfig(config)
+	if err != nil {
+		t.Fatalf("NewGameFromConfig() error: %v", err)
+	}
+	game.Start(80, 24)
+	game.ProcessInput('o')
+	for game.settingsMenu.Items[game.settingsMenu.Current()].Label != "Key: Pause" {
+		game.ProcessInput(KeyDown)
+	}
+
+	game.ProcessInput(KeyEnter)
+	if !strings.Contains(game.Render(), "Press the new key for Pause") {
+		t.Error("Expected a prompt for the new key")
+	}
+	game.ProcessInput('p')
+	if !strings.Contains(game.MenuMessage, "typed in words") || game.Bindings.Key(ActionPause) != KeyEscape {
+		t.Errorf("Expected a letter to be refused, got %q", game.MenuMessage)
+	}
+	game.ProcessInput(KeyEnter)
+	game.ProcessInput(16) // Ctrl+P
+	if game.Bindings.Key(ActionPause) != 16 {
+		t.Fatalf("Expected pause on CTRL+P, got %s", game.Bindings.Name(ActionPause))
+	}
+	loaded, _ := LoadConfig(path)
+	if strings.Join(loaded.Bindings["pause"], " ") != "ctrl+p" {
+		t.Errorf("Expected the binding in the config file, got %v", loaded.Bindings)
+	}
+
+	game.ProcessInput(KeyDown)
+	for game.settingsMenu.Items[game.settingsMenu.Current()].Label != "Reset keys" {
+		game.ProcessInput(KeyDown)
+	}
+	game.ProcessInput(KeyEnter)
+	if game.Bindings.Key(ActionPause) != KeyEscape {
+		t.Errorf("Expected the default bindings back, got %s", game.Bindings.Name(ActionPause))
+	}
+}
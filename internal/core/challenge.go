@@ -0,0 +1,198 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	wordSeedSalt       = 0x5eed5eed // separates the word stream from the platform stream
+	maxChallengeLength = 96         // longest code accepted in the menu input
+)
+
+// ErrInvalidChallenge is returned for challenge codes that can't be decoded
+var ErrInvalidChallenge = errors.New("invalid challenge code")
+
+// RunSettings are the settings that fully determine a course: two runs with
+// the same settings get the same words and the same platforms, whatever the
+// size of the terminal and the lives.
+type RunSettings struct {
+	Mode       string `json:"mode"`
+	Pack       string `json:"pack"`
+	Difficulty int    `json:"difficulty"`
+	Seed       int64  `json:"seed"`
+}
+
+// newSeed returns a fresh seed for a random course
+func newSeed() int64 {
+	return time.Now().UnixNano()
+}
+
+// EncodeChallenge turns settings and the starting lives into a short code
+// that can be shared and pasted into the menu, e.g.
+// "sprint60-common-2-0-ddlgee7v9vwr-m8". Custom runs have no code.
+func EncodeChallenge(s RunSettings, lives int) (string, error) {
+	if err := checkShareable(s); err != nil {
+		return "", err
+	}
+	payload := fmt.Sprintf("%s-%s-%d-%d-%s", s.Mode, s.Pack, s.Difficulty, lives,
+		strconv.FormatUint(uint64(s.Seed), 36))
+	return payload + "-" + challengeChecksum(payload), nil
+}
+
+// checkShareable refuses Custom, which every player tunes in their own config
+// file, so a code on it wouldn't play the same course for everybody
+func checkShareable(s RunSettings) error {
+	if s.Difficulty == DifficultyCustom {
+		return fmt.Errorf("%w: Custom difficulty is tuned by every player, it can't be shared", ErrInvalidChallenge)
+	}
+	return nil
+}
+
+// DecodeChallenge parses a challenge code back into run settings and the
+// starting lives
+func DecodeChallenge(code string) (RunSettings, int, error) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	parts := strings.Split(code, "-")
+	if len(parts) != 6 {
+		return RunSettings{}, 0, fmt.Errorf("%w: expected 6 parts, got %d", ErrInvalidChallenge, len(parts))
+	}
+	if parts[5] != challengeChecksum(strings.Join(parts[:5], "-")) {
+		return RunSettings{}, 0, fmt.Errorf("%w: checksum mismatch, check for typos", ErrInvalidChallenge)
+	}
+
+	difficulty, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return RunSettings{}, 0, fmt.Errorf("%w: bad difficulty %q", ErrInvalidChallenge, parts[2])
+	}
+	lives, err := strconv.Atoi(parts[3])
+	if err != nil || !slices.Contains(livesOptions, lives) {
+		return RunSettings{}, 0, fmt.Errorf("%w: bad lives %q", ErrInvalidChallenge, parts[3])
+	}
+	seed, err := strconv.ParseUint(parts[4], 36, 64)
+	if err != nil {
+		return RunSettings{}, 0, fmt.Errorf("%w: bad seed %q", ErrInvalidChallenge, parts[4])
+	}
+
+	settings := RunSettings{
+		Mode:       parts[0],
+		Pack:       parts[1],
+		Difficulty: difficulty,
+		Seed:       int64(seed),
+	}
+	if err := settings.Validate(); err != nil {
+		return RunSettings{}, 0, err
+	}
+	if err := checkShareable(settings); err != nil {
+		return RunSettings{}, 0, err
+	}
+	return settings, lives, nil
+}
+
+// isChallengeChar reports whether r can be part of a challenge code: the
+// characters of mode, pack and layout IDs, and the separator
+func isChallengeChar(r rune) bool {
+	return isAlphanumeric(r) || r == '-' || r == ':' || r == '_'
+}
+
+// Validate checks that the settings refer to modes and packs this build knows
+func (s RunSettings) Validate() error {
+	_, _, err := s.resolve()
+	return err
+}
+
+// resolve looks up the mode and builds the word pack of the settings
+func (s RunSettings) resolve() (GameMode, *WordPack, error) {
+	mode, ok := ModeByID(s.Mode)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: unknown mode %q", ErrInvalidChallenge, s.Mode)
+	}
+	pack, ok := WordPackByID(s.Pack)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: unknown word pack %q", ErrInvalidChallenge, s.Pack)
+	}
+	if _, ok := PresetByLevel(s.Difficulty); !ok {
+		return nil, nil, fmt.Errorf("%w: difficulty %d out of range", ErrInvalidChallenge, s.Difficulty)
+	}
+	return mode, pack, nil
+}
+
+// challengeChecksum returns two base36 characters guarding against typos
+func challengeChecksum(payload string) string {
+	sum := strconv.FormatUint(uint64(crc32.ChecksumIEEE([]byte(payload))%(36*36)), 36)
+	if len(sum) < 2 {
+		sum = "0" + sum
+	}
+	return sum
+}
+
+// RunSettings returns the settings of the current or upcoming run
+func (g *Game) RunSettings() RunSettings {
+	return RunSettings{
+		Mode:       g.Mode.ID(),
+		Pack:       g.WordManager.Pack,
+		Difficulty: g.WordManager.Difficulty,
+		Seed:       g.Seed,
+	}
+}
+
+// ApplySettings switches to the given settings and pins the seed, so every
+// following run plays the same course until the seed is released again
+func (g *Game) ApplySettings(s RunSettings) error {
+	mode, pack, err := s.resolve()
+	if err != nil {
+		return err
+	}
+	g.Mode = mode
+	g.WordManager.SetPack(pack)
+	g.SetDifficulty(s.Difficulty)
+	g.Seed = s.Seed
+	g.FixedSeed = true
+	g.Logger.Printf("ApplySettings: %+v", s)
+	return nil
+}
+
+// seedRun seeds every source of randomness for a new run. Words and platforms
+// use separate streams so the two never shift each other.
+func (g *Game) seedRun() {
+	if !g.FixedSeed {
+		g.Seed = newSeed()
+	}
+	g.rng = newSeededRand(g.Seed)
+	g.WordManager.Seed(g.Seed ^ wordSeedSalt)
+}
+
+// processCodeInput edits the challenge code typed or pasted into the menu
+func (g *Game) processCodeInput(key rune) {
+	switch key {
+	case 27: // ESC - cancel
+		g.codeEntry = false
+		g.codeInput = ""
+	case '\r', '\n': // Enter - apply
+		settings, lives, err := DecodeChallenge(g.codeInput)
+		if err != nil {
+			g.MenuMessage = err.Error()
+			return
+		}
+		g.ClearGhost()
+		g.ApplySettings(settings)
+		g.StartingLives = lives
+		code, _ := EncodeChallenge(settings, lives) // Decoded, so it can be shared
+		g.MenuMessage = g.tr("Challenge loaded: %s", code)
+		g.codeEntry = false
+		g.codeInput = ""
+	case 8, 127: // Backspace
+		if len(g.codeInput) > 0 {
+			g.codeInput = g.codeInput[:len(g.codeInput)-1]
+		}
+	default:
+		if isChallengeChar(key) && len(g.codeInput) < maxChallengeLength {
+			g.codeInput += string(key)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// mustEncode returns the challenge code of settings
+func mustEncode(t *testing.T, s RunSettings, lives int) string {
+	t.Helper()
+	code, err := EncodeChallenge(s, lives)
+	if err != nil {
+		t.Fatalf("EncodeChallenge(%+v) error: %v", s, err)
+	}
+	return code
+}
+
+func TestChallengeRoundTrip(t *testing.T) {
+	settings := RunSettings{Mode: "sprint60", Pack: "programming", Difficulty: 2, Seed: -1234567890123}
+
+	code := mustEncode(t, settings, 3)
+	decoded, lives, err := DecodeChallenge(code)
+	if err != nil {
+		t.Fatalf("DecodeChallenge(%q) error: %v", code, err)
+	}
+	if decoded != settings || lives != 3 {
+		t.Errorf("Expected %+v with 3 lives after round trip, got %+v with %d", settings, decoded, lives)
+	}
+
+	// Codes are case and whitespace insensitive
+	if _, _, err := DecodeChallenge("  " + code + "\n"); err != nil {
+		t.Errorf("Expected padded code to decode, got %v", err)
+	}
+}
+
+func TestChallengeRejectsBadCodes(t *testing.T) {
+	valid := mustEncode(t, RunSettings{Mode: "survival", Pack: "classic", Difficulty: 1, Seed: 42}, 0)
+	typo := []byte(valid)
+	typo[len("survival-classic-1-0-")] = 'z'
+	payload := "survival-classic-5-0-16" // Custom
+	customCode := payload + "-" + challengeChecksum(payload)
+	noLives := "survival-classic-1-16"
+
+	tests := []string{
+		"",
+		"survival-classic-1",
+		string(typo),
+		mustEncode(t, RunSettings{Mode: "nosuchmode", Pack: "classic", Difficulty: 1, Seed: 42}, 0),
+		mustEncode(t, RunSettings{Mode: "survival", Pack: "nosuchpack", Difficulty: 1, Seed: 42}, 0),
+		mustEncode(t, RunSettings{Mode: "survival", Pack: "classic", Difficulty: 7, Seed: 42}, 0),
+		mustEncode(t, RunSettings{Mode: "survival", Pack: "classic", Difficulty: 1, Seed: 42}, 4),
+		customCode,
+		noLives + "-" + challengeChecksum(noLives),
+	}
+	for _, code := range tests {
+		if _, _, err := DecodeChallenge(code); !errors.Is(err, ErrInvalidChallenge) {
+			t.Errorf("DecodeChallenge(%q) = %v, expected ErrInvalidChallenge", code, err)
+		}
+	}
+
+	// Every player tunes Custom for themselves
+	custom := RunSettings{Mode: "survival", Pack: "classic", Difficulty: DifficultyCustom, Seed: 42}
+	if code, err := EncodeChallenge(custom, 0); !errors.Is(err, ErrInvalidChallenge) {
+		t.Errorf("EncodeChallenge(Custom) = %q, %v, expected ErrInvalidChallenge", code, err)
+	}
+}
+
+// seededGame starts a run with the given settings
+func seededGame(t *testing.T, settings RunSettings) *Game {
+	t.Helper()
+	game := newPlayingGame(t)
+	if err := game.ApplySettings(settings); err != nil {
+		t.Fatalf("ApplySettings() error: %v", err)
+	}
+	game.reset()
+	return game
+}
+
+// course returns the initial platforms plus the next n generated ones
+func course(game *Game, n int) []Platform {
+	platforms := append([]Platform(nil), game.Platforms...)
+	for i := 0; i < n; i++ {
+		width := 12 + game.rng.Intn(4)*6
+		platforms = append(platforms, game.newPlatform(game.randomPlatformX(width), 0, width))
+	}
+	return platforms
+}
+
+func TestSameSeedSameCourse(t *testing.T) {
+	settings := RunSettings{Mode: "survival", Pack: "common", Difficulty: 2, Seed: 99}
+
+	first := course(seededGame(t, settings), 50)
+	second := course(seededGame(t, settings), 50)
+	for i := range first {
+		a, b := first[i], second[i]
+		if a.Word != b.Word || a.X != b.X || a.Width != b.Width || a.Type != b.Type || a.PowerUp != b.PowerUp {
+			t.Fatalf("Expected identical platform %d, got %+v and %+v", i, a, b)
+		}
+	}
+
+	settings.Seed = 100
+	other := course(seededGame(t, settings), 50)
+	same := 0
+	for i := range first {
+		if first[i].Word == other[i].Word && first[i].X == other[i].X {
+			same++
+		}
+	}
+	if same == len(first) {
+		t.Error("Expected a different seed to produce a different course")
+	}
+}
+
+func TestMenuChallengeCodeEntry(t *testing.T) {
+	game := newMenuGame(t)
+
+	settings := RunSettings{Mode: "zen", Pack: "programming", Difficulty: 3, Seed: 7}
+	game.ProcessInput('c')
+	for _, ch := range mustEncode(t, settings, 0) {
+		game.ProcessInput(ch)
+	}
+	game.ProcessInput('\r')
+
+	if got := game.RunSettings(); got != settings {
+		t.Errorf("Expected menu to load %+v, got %+v", settings, got)
+	}
+	if !game.FixedSeed {
+		t.Error("Expected challenge seed to be pinned")
+	}
+
+	game.ProcessInput(' ')
+	word := game.Platforms[0].Word
+	game.State = StateMenu
+	game.ProcessInput(' ')
+	if game.Platforms[0].Word != word {
+		t.Errorf("Expected pinned seed to replay the same course, got %q then %q", word, game.Platforms[0].Word)
+	}
+
+	game.State = StateMenu
+	game.ProcessInput('c')
+	for _, ch := range "bogus" {
+		game.ProcessInput(ch)
+	}
+	game.ProcessInput('\r')
+	if game.MenuMessage == "" {
+		t.Error("Expected an error message for a bad code")
+	}
+}
+
+func TestCourseIgnoresScreenAndLives(t *testing.T) {
+	settings := RunSettings{Mode: "survival", Pack: "common", Difficulty: 2, Seed: 99}
+	first := course(seededGame(t, settings), 50)
+
+	game := newPlayingGame(t)
+	game.ApplySettings(settings)
+	game.StartingLives = 3
+	game.UpdateDimensions(132, 50)
+	game.reset()
+	second := course(game, 50)
+
+	for i := range first {
+		a, b := first[i], second[i]
+		// Checkpoints are plain platforms, everything else must match
+		if a.Word != b.Word || !b.Checkpoint && (a.Width != b.Width || a.Type != b.Type || a.PowerUp != b.PowerUp) {
+			t.Fatalf("Expected platform %d to be the same, got %+v and %+v", i, a, b)
+		}
+		if i > 0 && (b.X < 0 || b.X+b.Width > 132) {
+			t.Errorf("Expected platform %d on the wider screen, got X %d", i, b.X)
+		}
+	}
+}
+
+func TestMenuAcceptsEveryChallengeCode(t *testing.T) {
+	saved := slices.Clone(layouts)
+	t.Cleanup(func() { layouts = saved })
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "my_keys.txt"), []byte("name Mine\ntop qwertyuiop\nhome asdfghjkl\nbottom zxcvbnm"), 0644)
+	if err := LoadLayouts(dir); err != nil {
+		t.Fatalf("LoadLayouts() error: %v", err)
+	}
+
+	game := newMenuGame(t)
+	for _, pack := range []string{"common", "drill:home:my_keys", "lesson:numbers:qwerty", "pseudo:programming"} {
+		settings := RunSettings{Mode: "marathon50", Pack: pack, Difficulty: 4, Seed: -987654321}
+		game.ProcessInput('c')
+		for _, ch := range mustEncode(t, settings, 5) {
+			game.ProcessInput(ch)
+		}
+		game.ProcessInput('\r')
+		if got := game.RunSettings(); got != settings || game.StartingLives != 5 {
+			t.Errorf("Expected the menu to load %+v with 5 lives, got %+v with %d (%s)", settings, got, game.StartingLives, game.MenuMessage)
+		}
+	}
+}
+
+func TestApplySettingsBuildsThePackOnce(t *testing.T) {
+	game := newMenuGame(t)
+	plain := RunSettings{Mode: "zen", Pack: "classic", Difficulty: DifficultyEasy, Seed: 7}
+	pseudo := plain
+	pseudo.Pack = "pseudo:common"
+
+	build := testing.AllocsPerRun(5, func() { WordPackByID(pseudo.Pack) }) -
+		testing.AllocsPerRun(5, func() { WordPackByID(plain.Pack) })
+	apply := testing.AllocsPerRun(5, func() { game.ApplySettings(pseudo) }) -
+		testing.AllocsPerRun(5, func() { game.ApplySettings(plain) })
+	if apply > build*1.5 {
+		t.Errorf("Expected ApplySettings to build the pseudo-word pack once, it allocates %.0f for a build of %.0f", apply, build)
+	}
+}
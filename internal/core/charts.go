@@ -0,0 +1,118 @@
+package core
+
+import "strings"
+
+// sparkLevels are the characters of a sparkline, lowest first
+const sparkLevels = "_.-~=+*#"
+
+// sparkline draws values as one character each, scaled between the
+// smallest and largest value
+func sparkline(values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+
+	var sb strings.Builder
+	top := len(sparkLevels) - 1
+	for _, v := range values {
+		level := top / 2 // Flat lines sit in the middle
+		if hi > lo {
+			level = int((v-lo)/(hi-lo)*float64(top) + 0.5)
+		}
+		sb.WriteByte(sparkLevels[level])
+	}
+	return sb.String()
+}
+
+// personalBests reports for every value whether it beat all values before it
+func personalBests(values []float64) []bool {
+	bests := make([]bool, len(values))
+	best := 0.0
+	for i, v := range values {
+		if i == 0 || v > best {
+			bests[i] = true
+			best = v
+		}
+	}
+	return bests
+}
+
+// markers draws a caret under every marked position
+func markers(marked []bool) string {
+	var sb strings.Builder
+	for _, m := range marked {
+		if m {
+			sb.WriteByte('^')
+		} else {
+			sb.WriteByte(' ')
+		}
+	}
+	return sb.String()
+}
+
+// bar draws value as a horizontal bar of up to width characters, where
+// limit fills the whole width
+func bar(value, limit float64, width int) string {
+	filled := 0
+	if limit > 0 && value > 0 {
+		filled = min(int(value/limit*float64(width)+0.5), width)
+	}
+	return strings.Repeat("#", filled) + strings.Repeat(" ", width-filled)
+}
+
+// resample shrinks values to at most width columns by averaging buckets
+func resample(values []float64, width int) []float64 {
+	if len(values) <= width {
+		return values
+	}
+	columns := make([]float64, width)
+	for c := range columns {
+		from, to := c*len(values)/width, (c+1)*len(values)/width
+		sum := 0.0
+		for _, v := range values[from:to] {
+			sum += v
+		}
+		columns[c] = sum / float64(to-from)
+	}
+	return columns
+}
+
+// resampleMarks shrinks marks to at most width columns, a column is marked
+// if any of its bucket is
+func resampleMarks(marks []bool, width int) []bool {
+	if len(marks) <= width {
+		return marks
+	}
+	columns := make([]bool, width)
+	for c := range columns {
+		for _, m := range marks[c*len(marks)/width : (c+1)*len(marks)/width] {
+			columns[c] = columns[c] || m
+		}
+	}
+	return columns
+}
+
+// plot draws values as a point per column on rows lines, the top line first.
+// The top line holds values of limit and above, the bottom line zero.
+func plot(values []float64, rows int, limit float64) []string {
+	lines := make([][]byte, rows)
+	for i := range lines {
+		lines[i] = []byte(strings.Repeat(" ", len(values)))
+	}
+	for c, v := range values {
+		row := 0
+		if limit > 0 {
+			row = min(int(v/limit*float64(rows-1)+0.5), rows-1)
+		}
+		lines[rows-1-max(row, 0)][c] = '*'
+	}
+	plotted := make([]string, rows)
+	for i, line := range lines {
+		plotted[i] = string(line)
+	}
+	return plotted
+}
@@ -0,0 +1,64 @@
+package core
+
+import "time"
+
+// Clock tells the engine the wall time. The live game uses the system clock,
+// replays use a ManualClock so a replayed run sees exactly the recorded times.
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the real wall clock
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// ManualClock is a clock that only moves when told to
+type ManualClock struct {
+	t time.Time
+}
+
+// NewManualClock returns a clock stopped at t
+func NewManualClock(t time.Time) *ManualClock {
+	return &ManualClock{t: t}
+}
+
+// Now returns the time the clock was last set to
+func (c *ManualClock) Now() time.Time {
+	return c.t
+}
+
+// Set moves the clock to t
+func (c *ManualClock) Set(t time.Time) {
+	c.t = t
+}
+
+// Advance moves the clock forward by d
+func (c *ManualClock) Advance(d time.Duration) {
+	c.t = c.t.Add(d)
+}
+
+// now returns the engine's current time. Inside ProcessInput and a frame it is
+// read once per call, so everything a single input or frame does, including
+// the replay event it records, happens at the same instant.
+func (g *Game) now() time.Time {
+	if !g.callTime.IsZero() {
+		return g.callTime
+	}
+	if g.Clock == nil {
+		return time.Now()
+	}
+	return g.Clock.Now()
+}
+
+// freezeTime pins now() for the duration of one input or frame.
+// Call the returned function to release it.
+func (g *Game) freezeTime() func() {
+	if !g.callTime.IsZero() {
+		return func() {} // Already frozen by an outer call
+	}
+	g.callTime = g.now()
+	return func() { g.callTime = time.Time{} }
+}
@@ -0,0 +1,252 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	configDirName  = "ascii-type"
+	configFileName = "config.json"
+)
+
+// Clients the game can run in
+const (
+	ClientTerminal = "terminal"
+	ClientDummy    = "dummy" // DummyGame, for testing the terminal client
+)
+
+// ErrInvalidConfig is returned for config values the game can't use
+var ErrInvalidConfig = errors.New("invalid config")
+
+// SpeedCurve is how the scroll speed starts and ramps up over a run
+type SpeedCurve struct {
+	Start    float64 `json:"start"`    // pixels per second
+	Factor   float64 `json:"factor"`   // multiplier applied at every ramp
+	Interval int     `json:"interval"` // words between ramps
+	Max      float64 `json:"max"`      // speed the ramps stop at, 0 for no limit
+}
+
+// Validate checks that the curve is playable
+func (s SpeedCurve) Validate() error {
+	switch {
+	case s.Start <= 0 || s.Start > 60:
+		return fmt.Errorf("%w: speed start must be above 0 and at most 60, got %g", ErrInvalidConfig, s.Start)
+	case s.Factor < 1 || s.Factor > 2:
+		return fmt.Errorf("%w: speed factor must be between 1 and 2, got %g", ErrInvalidConfig, s.Factor)
+	case s.Interval < 1:
+		return fmt.Errorf("%w: speed interval must be at least 1 word, got %d", ErrInvalidConfig, s.Interval)
+	case s.Max != 0 && (s.Max < s.Start || s.Max > 100):
+		return fmt.Errorf("%w: speed max must be 0 or between the start speed and 100, got %g", ErrInvalidConfig, s.Max)
+	}
+	return nil
+}
+
+// Config is the user's configuration file. Empty run settings keep what the
+// active profile last picked in the menu.
+type Config struct {
+	Mode       string              `json:"mode"`       // mode ID
+	Pack       string              `json:"pack"`       // word pack ID
+	Difficulty string              `json:"difficulty"` // preset ID
+	Custom     Tuning              `json:"custom"`     // tuning of the Custom preset
+	Theme      string              `json:"theme"`
+	Errors     string              `json:"errors"`   // error policy ID
+	Accents    string              `json:"accents"`  // AccentsExact or AccentsIgnore
+	Locale     string              `json:"locale"`   // locale ID
+	Bindings   map[string][]string `json:"bindings"` // remapped actions, by action ID
+	Profile    string              `json:"profile"`  // profile to play as, empty for the last active one
+	LogPath    string              `json:"log_path"`
+	LogLevel   string              `json:"log_level"`
+	Client     string              `json:"client"`
+	path       string
+}
+
+// DefaultConfig returns the configuration used without a config file
+func DefaultConfig() *Config {
+	return &Config{
+		Custom:   normalTuning,
+		Theme:    themes[0].ID,
+		Errors:   string(errorPolicies[0]),
+		Accents:  AccentsExact,
+		Locale:   locales[0].ID,
+		LogPath:  "game_log.txt",
+		LogLevel: "info",
+		Client:   ClientTerminal,
+	}
+}
+
+// ConfigPath returns the config file in the user's config directory
+func ConfigPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, configDirName, configFileName), nil
+}
+
+// LoadConfig reads the config file at path on top of the defaults. A missing
+// file is not an error and yields the defaults.
+func LoadConfig(path string) (*Config, error) {
+	config := DefaultConfig()
+	config.path = path
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields() // Catch typos in option names
+	if err := decoder.Decode(config); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidConfig, path, err)
+	}
+	return config, nil
+}
+
+// Save writes the config back to the file it was loaded from
+func (c *Config) Save() error {
+	if c.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, data, 0644)
+}
+
+// Validate checks every value and names the first bad one along with the
+// values it could have been
+func (c *Config) Validate() error {
+	if c.Mode != "" {
+		if _, ok := ModeByID(c.Mode); !ok {
+			var ids []string
+			for _, mode := range gameModes {
+				ids = append(ids, mode.ID())
+			}
+			return invalidChoice("mode", c.Mode, ids)
+		}
+	}
+	if c.Pack != "" {
+		if _, ok := WordPackByID(c.Pack); !ok {
+			var ids []string
+			for _, pack := range wordPacks {
+				ids = append(ids, pack.ID)
+			}
+			return invalidChoice("pack", c.Pack, ids)
+		}
+	}
+	if c.Difficulty != "" {
+		if _, ok := PresetByID(c.Difficulty); !ok {
+			var ids []string
+			for _, preset := range presets {
+				ids = append(ids, preset.ID)
+			}
+			return invalidChoice("difficulty", c.Difficulty, ids)
+		}
+	}
+	if err := c.Custom.Validate(); err != nil {
+		return err
+	}
+	if _, ok := ThemeByID(c.Theme); !ok {
+		var ids []string
+		for _, theme := range themes {
+			ids = append(ids, theme.ID)
+		}
+		return invalidChoice("theme", c.Theme, ids)
+	}
+	if _, ok := ErrorPolicyByID(c.Errors); !ok {
+		var ids []string
+		for _, policy := range errorPolicies {
+			ids = append(ids, string(policy))
+		}
+		return invalidChoice("errors", c.Errors, ids)
+	}
+	if c.Accents != AccentsExact && c.Accents != AccentsIgnore {
+		return invalidChoice("accents", c.Accents, []string{AccentsExact, AccentsIgnore})
+	}
+	if _, ok := LocaleByID(c.Locale); !ok {
+		var ids []string
+		for _, locale := range locales {
+			ids = append(ids, locale.ID)
+		}
+		return invalidChoice("locale", c.Locale, ids)
+	}
+	if _, err := ParseBindings(c.Bindings); err != nil {
+		return err
+	}
+	if _, err := ParseLogLevel(c.LogLevel); err != nil {
+		return err
+	}
+	if c.LogPath == "" && c.LogLevel != "off" {
+		return fmt.Errorf("%w: log_path is empty, set log_level to off to disable logging", ErrInvalidConfig)
+	}
+	if c.Client != ClientTerminal && c.Client != ClientDummy {
+		return invalidChoice("client", c.Client, []string{ClientTerminal, ClientDummy})
+	}
+	return nil
+}
+
+func invalidChoice(name, value string, choices []string) error {
+	return fmt.Errorf("%w: unknown %s %q, expected one of %s", ErrInvalidConfig, name, value, strings.Join(choices, ", "))
+}
+
+// NewGameFromConfig creates a game set up by a validated config
+func NewGameFromConfig(c *Config) (*Game, error) {
+	level, err := ParseLogLevel(c.LogLevel)
+	if err != nil {
+		return nil, err
+	}
+	logger, err := NewLevelLogger(c.LogPath, level)
+	if err != nil {
+		return nil, err
+	}
+	game := newGameWithFiles(logger)
+	game.Config = c
+	game.ApplyConfig()
+	return game, nil
+}
+
+// ApplyConfig applies the run settings, custom tuning, theme, locale, error
+// policy, accent matching and key bindings of Config on top of the active
+// profile's settings
+func (g *Game) ApplyConfig() {
+	c := g.Config
+	if c == nil {
+		return
+	}
+	if mode, ok := ModeByID(c.Mode); ok {
+		g.Mode = mode
+	}
+	if pack, ok := WordPackByID(c.Pack); ok {
+		g.WordManager.SetPack(pack)
+	}
+	if preset, ok := PresetByID(c.Difficulty); ok {
+		g.SetDifficulty(presetLevel(preset))
+	} else {
+		g.SetDifficulty(g.WordManager.Difficulty) // Picks up the custom tuning
+	}
+	if theme, ok := ThemeByID(c.Theme); ok {
+		g.Theme = theme
+	}
+	if locale, ok := LocaleByID(c.Locale); ok {
+		g.Locale = locale
+	}
+	if policy, ok := ErrorPolicyByID(c.Errors); ok {
+		g.Errors = policy
+	}
+	g.WordManager.IgnoreAccents = c.Accents == AccentsIgnore
+	if bindings, err := ParseBindings(c.Bindings); err == nil {
+		g.SetBindings(bindings)
+	}
+}
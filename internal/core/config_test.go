@@ -0,0 +1,146 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	config, err := LoadConfig(filepath.Join(dir, "missing.json"))
+	if err != nil {
+		t.Fatalf("LoadConfig() error: %v", err)
+	}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected the defaults to be valid, got %v", err)
+	}
+
+	path := filepath.Join(dir, "nested", configFileName)
+	config, _ = LoadConfig(path)
+	config.Mode = "zen"
+	config.Custom.Speed.Factor = 1.2
+	if err := config.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	loaded, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error: %v", err)
+	}
+	if loaded.Mode != "zen" || loaded.Custom.Speed.Factor != 1.2 || loaded.LogLevel != "info" {
+		t.Errorf("Expected the saved config back, got %+v", loaded)
+	}
+
+	os.WriteFile(path, []byte(`{"mode": "zen", "speeed": {}}`), 0644)
+	if _, err := LoadConfig(path); !errors.Is(err, ErrInvalidConfig) || !strings.Contains(err.Error(), "speeed") {
+		t.Errorf("Expected an error naming the unknown option, got %v", err)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(c *Config)
+		want   string
+	}{
+		{"mode", func(c *Config) { c.Mode = "speedrun" }, `unknown mode "speedrun"`},
+		{"pack", func(c *Config) { c.Pack = "klingon" }, `unknown pack "klingon"`},
+		{"difficulty", func(c *Config) { c.Difficulty = "nightmare" }, "expected one of easy, normal, hard, insane, custom"},
+		{"speed start", func(c *Config) { c.Custom.Speed.Start = 0 }, "speed start"},
+		{"speed factor", func(c *Config) { c.Custom.Speed.Factor = 0.5 }, "speed factor"},
+		{"speed interval", func(c *Config) { c.Custom.Speed.Interval = 0 }, "speed interval"},
+		{"speed max", func(c *Config) { c.Custom.Speed.Max = 1 }, "speed max"},
+		{"word lengths", func(c *Config) { c.Custom.MaxWord = 2 }, "longest word"},
+		{"spacing", func(c *Config) { c.Custom.Spacing = 2 }, "platform spacing"},
+		{"theme", func(c *Config) { c.Theme = "neon" }, "expected one of classic, mono, light"},
+		{"errors", func(c *Config) { c.Errors = "ignore" }, "expected one of stop, allow, skip"},
+		{"accents", func(c *Config) { c.Accents = "loose" }, "expected one of exact, ignore"},
+		{"log level", func(c *Config) { c.LogLevel = "verbose" }, "unknown log level"},
+		{"log path", func(c *Config) { c.LogPath = "" }, "log_path"},
+		{"client", func(c *Config) { c.Client = "web" }, `unknown client "web"`},
+	}
+	for _, tt := range tests {
+		config := DefaultConfig()
+		tt.change(config)
+		err := config.Validate()
+		if !errors.Is(err, ErrInvalidConfig) || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: expected an error containing %q, got %v", tt.name, tt.want, err)
+		}
+	}
+
+	config := DefaultConfig()
+	config.LogPath = ""
+	config.LogLevel = "off"
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected no log path to be fine with logging off, got %v", err)
+	}
+}
+
+func TestNewGameFromConfig(t *testing.T) {
+	config := DefaultConfig()
+	config.Mode = "zen"
+	config.Difficulty = "custom"
+	config.Custom.Speed = SpeedCurve{Start: 8, Factor: 1.5, Interval: 2}
+	config.Theme = "mono"
+	config.LogLevel = "off"
+	game, err := NewGameFromConfig(config)
+	if err != nil {
+		t.Fatalf("NewGameFromConfig() error: %v", err)
+	}
+	game.HighScores = nil
+	game.Start(80, 24)
+	game.ProcessInput(' ')
+
+	if game.Mode.ID() != "zen" || game.WordManager.Difficulty != DifficultyCustom || game.ScrollSpeed != 8 {
+		t.Errorf("Expected the configured mode, difficulty and speed, got %s, %d, %.1f",
+			game.Mode.ID(), game.WordManager.Difficulty, game.ScrollSpeed)
+	}
+	typeCurrentWord(game, false)
+	typeCurrentWord(game, false)
+	if game.ScrollSpeed != 12 {
+		t.Errorf("Expected the speed to ramp after 2 words, got %.1f", game.ScrollSpeed)
+	}
+	if frame := game.Render(); strings.Contains(frame, ColorGreen) || strings.Contains(frame, ColorWhite) {
+		t.Error("Expected the mono theme to drop colors")
+	}
+}
+
+func TestReplayKeepsTuning(t *testing.T) {
+	game := newPlayingGame(t)
+	game.State = StateMenu
+	clock := NewManualClock(time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC))
+	game.Clock = clock
+	game.ApplySettings(RunSettings{Mode: "survival", Pack: "classic", Difficulty: DifficultyEasy, Seed: 7})
+	game.SetTuning(Tuning{Speed: SpeedCurve{Start: 7, Factor: 1.5, Interval: 1}, MinWord: 3, MaxWord: 5, Spacing: 7})
+	game.ProcessInput(' ')
+	for i := 0; i < 3; i++ {
+		for _, ch := range game.Platforms[game.Player.Platform].Word {
+			clock.Advance(200 * time.Millisecond)
+			for f := 0; f < 12; f++ {
+				game.Render()
+			}
+			game.ProcessInput(ch)
+		}
+	}
+	for f := 0; f < 60; f++ {
+		clock.Advance(time.Second / 60)
+		game.Render()
+	}
+	game.ProcessInput(27)
+	clock.Advance(time.Second)
+	game.ProcessInput('\r')
+
+	if game.LastReplay.Tuning != game.Tuning {
+		t.Fatalf("Expected the replay to record the tuning, got %+v", game.LastReplay.Tuning)
+	}
+	v, err := VerifyReplay(game.LastReplay)
+	if err != nil {
+		t.Fatalf("VerifyReplay() error: %v", err)
+	}
+	if !v.OK() {
+		t.Errorf("Expected the run to replay with its own tuning, got %v", v.Issues)
+	}
+}
@@ -0,0 +1,238 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"hash/fnv"
+	"os"
+	"sort"
+	"time"
+)
+
+const (
+	dailyHistoryFile = "daily.json"
+	dateLayout       = "2006-01-02"
+	dailyMode        = "sprint60" // fixed length runs keep daily results comparable
+	dailyPack        = "common"
+	dailyDifficulty  = 2
+	dailyLives       = 0          // no respawns, like the default
+	dailyErrors      = ErrorsStop // the default policy, skipping errors would finish words faster
+)
+
+// menuChoices are the player's own run choices, put aside while the daily
+// challenge plays with the same ones for everybody
+type menuChoices struct {
+	settings  RunSettings
+	fixedSeed bool
+	lives     int
+	errors    ErrorPolicy
+	accents   bool
+}
+
+// DailySettings returns the course everyone plays on the given day. The
+// seed only depends on the UTC date, so no server is needed to agree on it.
+func DailySettings(day time.Time) RunSettings {
+	h := fnv.New64a()
+	h.Write([]byte("daily-" + day.UTC().Format(dateLayout)))
+	return RunSettings{
+		Mode:       dailyMode,
+		Pack:       dailyPack,
+		Difficulty: dailyDifficulty,
+		Seed:       int64(h.Sum64()),
+	}
+}
+
+// DailyResult is the scored attempt of a single day
+type DailyResult struct {
+	Date     string  `json:"date"` // UTC date, YYYY-MM-DD
+	Score    int     `json:"score"`
+	WPM      float64 `json:"wpm"`
+	Accuracy float64 `json:"accuracy"`
+	Words    int     `json:"words"`
+}
+
+// DailyHistory keeps one result per day
+type DailyHistory struct {
+	Results map[string]DailyResult `json:"results"`
+	path    string
+}
+
+// LoadDailyHistory reads the daily history from path.
+// A missing file is not an error and yields an empty history.
+func LoadDailyHistory(path string) (*DailyHistory, error) {
+	history := &DailyHistory{
+		Results: make(map[string]DailyResult),
+		path:    path,
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return history, nil
+	}
+	if err != nil {
+		return history, err
+	}
+	if err := json.Unmarshal(data, history); err != nil {
+		return history, err
+	}
+	if history.Results == nil {
+		history.Results = make(map[string]DailyResult)
+	}
+	return history, nil
+}
+
+// Save writes the history back to the file it was loaded from
+func (h *DailyHistory) Save() error {
+	if h.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(h.path, data, 0644)
+}
+
+// Played reports whether the scored attempt of the day has been used
+func (h *DailyHistory) Played(day time.Time) bool {
+	_, ok := h.Results[day.UTC().Format(dateLayout)]
+	return ok
+}
+
+// Result returns the result of a day, if it was played
+func (h *DailyHistory) Result(day time.Time) (DailyResult, bool) {
+	result, ok := h.Results[day.UTC().Format(dateLayout)]
+	return result, ok
+}
+
+// Record stores the result of a day's scored attempt. Only the first
+// attempt of a day counts; it returns false if the day was already played.
+func (h *DailyHistory) Record(day time.Time, stats Stats) bool {
+	if h.Played(day) {
+		return false
+	}
+	h.Update(day.UTC().Format(dateLayout), stats)
+	return true
+}
+
+// Update sets the result of the attempt of date, YYYY-MM-DD
+func (h *DailyHistory) Update(date string, stats Stats) {
+	h.Results[date] = DailyResult{
+		Date:     date,
+		Score:    stats.Score,
+		WPM:      stats.WPM,
+		Accuracy: stats.Accuracy,
+		Words:    stats.WordsTyped,
+	}
+}
+
+// Streak returns the number of consecutive days played up to today. A
+// streak is still alive if today hasn't been played yet but yesterday was.
+func (h *DailyHistory) Streak(today time.Time) int {
+	day := today.UTC()
+	if !h.Played(day) {
+		day = day.AddDate(0, 0, -1)
+	}
+	streak := 0
+	for h.Played(day) {
+		streak++
+		day = day.AddDate(0, 0, -1)
+	}
+	return streak
+}
+
+// BestStreak returns the longest run of consecutive days ever played
+func (h *DailyHistory) BestStreak() int {
+	dates := make([]string, 0, len(h.Results))
+	for date := range h.Results {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+
+	best, current := 0, 0
+	var previous time.Time
+	for _, date := range dates {
+		day, err := time.Parse(dateLayout, date)
+		if err != nil {
+			continue
+		}
+		if current > 0 && day.Equal(previous.AddDate(0, 0, 1)) {
+			current++
+		} else {
+			current = 1
+		}
+		if current > best {
+			best = current
+		}
+		previous = day
+	}
+	return best
+}
+
+// startDaily sets up today's daily challenge. The first attempt of the day
+// is scored, any further attempts are practice. The scored attempt is used up
+// as it starts, so quitting or saving the run doesn't give another one.
+func (g *Game) startDaily() {
+	today := g.now().UTC()
+	if !g.Daily {
+		g.keepMenuChoices()
+	}
+	g.ApplySettings(DailySettings(today))
+	g.StartingLives = dailyLives
+	g.Errors = dailyErrors
+	g.WordManager.IgnoreAccents = false
+	g.Daily = true
+	g.DailyScored = g.DailyHistory != nil && g.DailyHistory.Record(today, Stats{})
+	g.DailyDate = ""
+	if g.DailyScored {
+		g.DailyDate = today.Format(dateLayout)
+		g.saveDailyHistory()
+	}
+	g.Logger.Printf("startDaily: date=%s scored=%v", today.Format(dateLayout), g.DailyScored)
+	g.State = StatePlaying
+	g.reset()
+}
+
+// recordDaily stores the result of a scored daily attempt under the day it
+// started on
+func (g *Game) recordDaily() {
+	if !g.Daily || !g.DailyScored || g.DailyHistory == nil {
+		return
+	}
+	g.DailyScored = false // Retries of the day are practice
+	g.DailyHistory.Update(g.DailyDate, g.GetStats())
+	g.DailyRecorded = true
+	g.saveDailyHistory()
+}
+
+func (g *Game) saveDailyHistory() {
+	if err := g.DailyHistory.Save(); err != nil {
+		g.Logger.Printf("saveDailyHistory: failed to save daily history: %v", err)
+	}
+}
+
+// keepMenuChoices puts the player's run choices aside before a daily challenge
+func (g *Game) keepMenuChoices() {
+	g.menuChoices = menuChoices{
+		settings:  g.RunSettings(),
+		fixedSeed: g.FixedSeed,
+		lives:     g.StartingLives,
+		errors:    g.Errors,
+		accents:   g.WordManager.IgnoreAccents,
+	}
+}
+
+// leaveDaily returns to the choices made before the daily challenge, so the
+// next run doesn't play the daily course again
+func (g *Game) leaveDaily() {
+	if !g.Daily {
+		return
+	}
+	g.Daily = false
+	c := g.menuChoices
+	g.ApplySettings(c.settings)
+	g.FixedSeed = c.fixedSeed
+	g.StartingLives = c.lives
+	g.Errors = c.errors
+	g.WordManager.IgnoreAccents = c.accents
+}
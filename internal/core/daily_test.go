@@ -0,0 +1,202 @@
+package core
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDailySettings(t *testing.T) {
+	day := time.Date(2024, 3, 9, 8, 0, 0, 0, time.UTC)
+	later := time.Date(2024, 3, 9, 23, 59, 0, 0, time.UTC)
+
+	settings := DailySettings(day)
+	if settings != DailySettings(later) {
+		t.Errorf("Expected the same settings all day, got %+v and %+v", settings, DailySettings(later))
+	}
+	if err := settings.Validate(); err != nil {
+		t.Errorf("Expected valid daily settings, got %v", err)
+	}
+	if next := DailySettings(day.AddDate(0, 0, 1)); next.Seed == settings.Seed {
+		t.Error("Expected a different seed on the next day")
+	}
+}
+
+func TestDailyHistoryRecordsFirstAttemptOnly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "daily.json")
+	history, err := LoadDailyHistory(path)
+	if err != nil {
+		t.Fatalf("LoadDailyHistory() error: %v", err)
+	}
+
+	day := time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC)
+	if !history.Record(day, Stats{Score: 100, WPM: 50}) {
+		t.Fatal("Expected the first attempt to be recorded")
+	}
+	if history.Record(day, Stats{Score: 900, WPM: 90}) {
+		t.Error("Expected a second attempt on the same day to be rejected")
+	}
+	if err := history.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	loaded, err := LoadDailyHistory(path)
+	if err != nil {
+		t.Fatalf("LoadDailyHistory() error: %v", err)
+	}
+	result, ok := loaded.Result(day)
+	if !ok || result.Score != 100 {
+		t.Errorf("Expected the first result to be kept, got %+v (played=%v)", result, ok)
+	}
+}
+
+func TestDailyStreaks(t *testing.T) {
+	history := &DailyHistory{Results: map[string]DailyResult{}}
+	today := time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC)
+
+	for _, daysAgo := range []int{1, 2, 3, 6, 7, 8, 9} {
+		history.Record(today.AddDate(0, 0, -daysAgo), Stats{})
+	}
+	// Today isn't played yet, but yesterday keeps the streak alive
+	if streak := history.Streak(today); streak != 3 {
+		t.Errorf("Expected a streak of 3, got %d", streak)
+	}
+	if best := history.BestStreak(); best != 4 {
+		t.Errorf("Expected a best streak of 4, got %d", best)
+	}
+	if streak := history.Streak(today.AddDate(0, 0, 1)); streak != 0 {
+		t.Errorf("Expected a missed day to break the streak, got %d", streak)
+	}
+}
+
+func TestDailyScoredThenPractice(t *testing.T) {
+	game := newPlayingGame(t)
+	history, err := LoadDailyHistory(filepath.Join(t.TempDir(), "daily.json"))
+	if err != nil {
+		t.Fatalf("LoadDailyHistory() error: %v", err)
+	}
+	game.DailyHistory = history
+
+	game.State = StateMenu
+	game.ProcessInput('y')
+	if game.State != StatePlaying || !game.Daily || !game.DailyScored {
+		t.Fatalf("Expected a scored daily run, got state=%v daily=%v scored=%v", game.State, game.Daily, game.DailyScored)
+	}
+	if got, want := game.RunSettings(), DailySettings(time.Now()); got != want {
+		t.Errorf("Expected daily settings %+v, got %+v", want, got)
+	}
+	typeCurrentWord(game, false)
+	game.endRun("test")
+	if !game.DailyRecorded || !history.Played(time.Now()) {
+		t.Fatal("Expected the first daily attempt to be recorded")
+	}
+	first, _ := history.Result(time.Now())
+
+	// Another attempt on the same day is practice
+	game.State = StateMenu
+	game.ProcessInput('y')
+	if game.DailyScored {
+		t.Error("Expected the second daily attempt to be practice")
+	}
+	typeCurrentWord(game, false)
+	typeCurrentWord(game, false)
+	game.endRun("test")
+	if game.DailyRecorded {
+		t.Error("Expected a practice run not to be recorded")
+	}
+	if result, _ := history.Result(time.Now()); result != first {
+		t.Errorf("Expected the recorded result to stay %+v, got %+v", first, result)
+	}
+
+	// Starting a normal run leaves the daily
+	game.State = StateMenu
+	game.ProcessInput(' ')
+	if game.Daily {
+		t.Error("Expected a normal run not to be a daily run")
+	}
+}
+
+func TestDailyAttemptUsedAtStart(t *testing.T) {
+	game := newPlayingGame(t)
+	history, err := LoadDailyHistory(filepath.Join(t.TempDir(), "daily.json"))
+	if err != nil {
+		t.Fatalf("LoadDailyHistory() error: %v", err)
+	}
+	game.DailyHistory = history
+	clock := NewManualClock(time.Date(2024, 3, 9, 23, 59, 30, 0, time.UTC))
+	game.Clock = clock
+
+	game.State = StateMenu
+	game.ProcessInput('y')
+	if !game.DailyScored || !history.Played(clock.Now()) {
+		t.Fatal("Expected the scored attempt to be used up as it starts")
+	}
+	loaded, err := LoadDailyHistory(history.path)
+	if err != nil || !loaded.Played(clock.Now()) {
+		t.Errorf("Expected the started attempt to be saved, got %v", err)
+	}
+
+	// The run crosses midnight and is filed under the day it started on
+	clock.Advance(time.Minute)
+	typeCurrentWord(game, false)
+	game.endRun("test")
+	if _, ok := history.Result(clock.Now()); ok {
+		t.Error("Expected nothing recorded for the day the run ended on")
+	}
+	if result, ok := history.Result(clock.Now().AddDate(0, 0, -1)); !ok || result.Words != 1 {
+		t.Errorf("Expected the result on the day the run started, got %+v", result)
+	}
+}
+
+func TestDailyQuitLeavesAttemptUsed(t *testing.T) {
+	game := newPlayingGame(t)
+	history, err := LoadDailyHistory(filepath.Join(t.TempDir(), "daily.json"))
+	if err != nil {
+		t.Fatalf("LoadDailyHistory() error: %v", err)
+	}
+	game.DailyHistory = history
+	game.SaveFile = filepath.Join(t.TempDir(), "savegame.json")
+
+	game.State = StateMenu
+	game.ProcessInput('y')
+	game.ProcessInput(KeyEscape) // Pause
+	game.ProcessInput('s')       // Save and back to the menu
+	game.SavedRun = nil          // As if the save was lost
+
+	game.ProcessInput('y')
+	if game.DailyScored {
+		t.Error("Expected the daily after a saved attempt to be practice")
+	}
+}
+
+func TestDailyRestoresSettings(t *testing.T) {
+	game := newPlayingGame(t)
+	game.DailyHistory = nil
+	game.State = StateMenu
+	game.ApplySettings(RunSettings{Mode: "zen", Pack: "classic", Difficulty: DifficultyHard, Seed: 7})
+	game.FixedSeed = false
+	game.StartingLives = 3
+	game.Errors = ErrorsSkip
+	game.WordManager.IgnoreAccents = true
+
+	game.ProcessInput('y')
+	if game.Mode.ID() != dailyMode {
+		t.Fatalf("Expected the daily mode, got %s", game.Mode.ID())
+	}
+	// Everybody plays the daily the same way
+	if game.Lives != dailyLives || game.Errors != dailyErrors || game.WordManager.IgnoreAccents {
+		t.Errorf("Expected the daily's lives, error policy and accents, got %d, %s and %v",
+			game.Lives, game.Errors, game.WordManager.IgnoreAccents)
+	}
+	game.endRun("test")
+	game.ProcessInput('m')
+
+	got := game.RunSettings()
+	if got.Mode != "zen" || got.Pack != "classic" || got.Difficulty != DifficultyHard || game.FixedSeed || game.Daily {
+		t.Errorf("Expected the settings from before the daily, got %+v fixed=%v daily=%v", got, game.FixedSeed, game.Daily)
+	}
+	if game.StartingLives != 3 || game.Errors != ErrorsSkip || !game.WordManager.IgnoreAccents {
+		t.Errorf("Expected the lives, error policy and accents from before the daily, got %d, %s and %v",
+			game.StartingLives, game.Errors, game.WordManager.IgnoreAccents)
+	}
+}
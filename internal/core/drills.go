@@ -0,0 +1,158 @@
+package core
+
+import (
+	"hash/crc32"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// drillPrefix starts the pack IDs of drills, "drill:<drill>:<layout>"
+const drillPrefix = "drill:"
+
+// drillWords is how many words a drill pack holds
+const drillWords = 60
+
+// Drill practices part of a keyboard layout with words made of its keys
+type Drill struct {
+	ID        string
+	Name      string
+	Keys      func(KeyPosition) bool // keys the words are made of
+	Alternate bool                   // every key is typed by the other hand than the one before
+}
+
+// drills holds every drill in keyboard screen order
+var drills = []Drill{
+	{ID: "home", Name: "Home row", Keys: func(p KeyPosition) bool { return p.Row == RowHome }},
+	{ID: "top", Name: "Home and top rows", Keys: func(p KeyPosition) bool { return p.Row == RowHome || p.Row == RowTop }},
+	{ID: "bottom", Name: "Home and bottom rows", Keys: func(p KeyPosition) bool { return p.Row == RowHome || p.Row == RowBottom }},
+	{ID: "left", Name: "Left hand", Keys: func(p KeyPosition) bool { return p.Finger.Hand() == HandLeft }},
+	{ID: "right", Name: "Right hand", Keys: func(p KeyPosition) bool { return p.Finger.Hand() == HandRight }},
+	{ID: "alternate", Name: "Hand alternation", Keys: func(KeyPosition) bool { return true }, Alternate: true},
+}
+
+// Drills returns every drill in keyboard screen order
+func Drills() []Drill {
+	return drills
+}
+
+// DrillPackID returns the ID of the word pack of drill on layout
+func DrillPackID(drill Drill, layout *Layout) string {
+	return drillPrefix + drill.ID + ":" + layout.ID
+}
+
+// drillPackByID builds the word pack of a drill pack ID
+func drillPackByID(id string) (*WordPack, bool) {
+	parts := strings.Split(strings.TrimPrefix(id, drillPrefix), ":")
+	if len(parts) != 2 {
+		return nil, false
+	}
+	i := slices.IndexFunc(drills, func(d Drill) bool { return d.ID == parts[0] })
+	layout, ok := LayoutByID(parts[1])
+	if i < 0 || !ok {
+		return nil, false
+	}
+	return drillPack(drills[i], layout)
+}
+
+// drillPack builds the words of drill on layout: pseudo-words of its keys,
+// falling back to the words of the bundled packs that only use its keys,
+// topped up with letter groups of them. Hand alternation has no pseudo-words.
+// The same drill and layout always yield the same words for a seed, so drill
+// runs can be replayed and shared like any other. It returns false if the
+// layout has no letters for the drill.
+func drillPack(drill Drill, layout *Layout) (*WordPack, bool) {
+	var letters []rune
+	hands := [2][]rune{}
+	for _, row := range layout.Rows {
+		for _, key := range row {
+			pos, _ := layout.Key(key)
+			if unicode.IsLetter(key) && isAlphanumeric(key) && drill.Keys(pos) {
+				letters = append(letters, key)
+				hands[pos.Finger.Hand()] = append(hands[pos.Finger.Hand()], key)
+			}
+		}
+	}
+	if len(letters) == 0 || drill.Alternate && (len(hands[HandLeft]) == 0 || len(hands[HandRight]) == 0) {
+		return nil, false
+	}
+
+	fits := func(word string) bool {
+		previous := rune(-1)
+		for _, r := range word {
+			if !slices.Contains(letters, r) {
+				return false
+			}
+			if drill.Alternate && previous >= 0 {
+				prev, _ := layout.Key(previous)
+				pos, _ := layout.Key(r)
+				if prev.Finger.Hand() == pos.Finger.Hand() {
+					return false
+				}
+			}
+			previous = r
+		}
+		return true
+	}
+	id := DrillPackID(drill, layout)
+	pack := &WordPack{ID: id, Name: drill.Name + " drill (" + layout.Name + ")"}
+	pack.Words = practiceWords(id, fits, func(rng *seededRand) string {
+		group := make([]rune, 3+rng.Intn(5))
+		hand := rng.Intn(2)
+		for i := range group {
+			if drill.Alternate {
+				keys := hands[(hand+i)%2]
+				group[i] = keys[rng.Intn(len(keys))]
+			} else {
+				group[i] = letters[rng.Intn(len(letters))]
+			}
+		}
+		return string(group)
+	})
+	if !drill.Alternate {
+		pack.Source = &PseudoWords{Model: bundledModel(), Letters: string(letters)}
+	}
+	return pack, true
+}
+
+// practiceWords picks drillWords words for a practice pack: the words of
+// the bundled packs that fit, topped up with groups from a generator seeded
+// with id, so the same id always yields the same words
+func practiceWords(id string, fits func(word string) bool, group func(rng *seededRand) string) []string {
+	var words []string
+	seen := make(map[string]bool)
+	for _, source := range wordPacks {
+		if source.Language != "en" {
+			continue // The layouts are made for English
+		}
+		for _, word := range source.Words {
+			word = strings.ToLower(word)
+			if len(words) < drillWords && len(word) >= 2 && !seen[word] && fits(word) {
+				seen[word] = true
+				words = append(words, word)
+			}
+		}
+	}
+
+	rng := newSeededRand(int64(crc32.ChecksumIEEE([]byte(id))))
+	for attempts := 0; len(words) < drillWords && attempts < drillWords*10; attempts++ {
+		if word := group(rng); !seen[word] {
+			seen[word] = true
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// startDrill starts a run of drill on the active profile's layout
+func (g *Game) startDrill(drill Drill) {
+	pack, ok := drillPack(drill, g.Layout)
+	if !ok {
+		g.MenuMessage = g.tr("The %s layout has no letters for the %s drill", g.Layout.Name, g.tr(drill.Name))
+		return
+	}
+	g.WordManager.SetPack(pack)
+	g.saveProfileSettings()
+	g.ClearGhost()
+	g.startRun()
+}
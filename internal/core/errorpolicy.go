@@ -0,0 +1,70 @@
+package core
+
+import (
+	"slices"
+	"time"
+	"unicode/utf8"
+)
+
+// errorFlashTime is how long the current word flashes red after a mistake
+const errorFlashTime = 200 * time.Millisecond
+
+// ErrorPolicy decides what a wrong key does to the word being typed
+type ErrorPolicy string
+
+const (
+	ErrorsStop  ErrorPolicy = "stop"  // wrong keys are refused, the word waits for the right one
+	ErrorsAllow ErrorPolicy = "allow" // wrong keys are typed and must be deleted before the word completes
+	ErrorsSkip  ErrorPolicy = "skip"  // wrong keys are typed and the word goes on past them
+)
+
+// errorPolicies holds every error policy in settings order; the first one is the default
+var errorPolicies = []ErrorPolicy{ErrorsStop, ErrorsAllow, ErrorsSkip}
+
+// ErrorPolicyByID looks up an error policy by its ID
+func ErrorPolicyByID(id string) (ErrorPolicy, bool) {
+	for _, policy := range errorPolicies {
+		if string(policy) == id {
+			return policy, true
+		}
+	}
+	return "", false
+}
+
+// Name returns how the policy is shown on screen
+func (p ErrorPolicy) Name() string {
+	switch p {
+	case ErrorsAllow:
+		return "Allow errors"
+	case ErrorsSkip:
+		return "Skip errors"
+	}
+	return "Stop on error"
+}
+
+// keyCorrect reports whether key is the next character of the current word.
+// Under ErrorsAllow nothing typed after an uncorrected wrong character is
+// correct, as the word can't complete until it is deleted.
+func (g *Game) keyCorrect(platform *Platform, key rune) bool {
+	if g.Errors == ErrorsAllow && slices.Contains(g.WordManager.typedErrors(platform.Word, platform.Typed), true) {
+		return false
+	}
+	return g.WordManager.IsValidChar(platform.Word, platform.Typed, key)
+}
+
+// typeKey adds key to what was typed of the current word as the error
+// policy says, and reports whether the word is now complete
+func (g *Game) typeKey(platform *Platform, key rune, correct bool) bool {
+	switch {
+	case correct:
+		platform.Typed += string(key)
+	case g.Errors == ErrorsStop || utf8.RuneCountInString(platform.Typed) >= utf8.RuneCountInString(platform.Word):
+		return false
+	default:
+		platform.Typed += string(key)
+	}
+	if g.Errors == ErrorsSkip {
+		return utf8.RuneCountInString(platform.Typed) == utf8.RuneCountInString(platform.Word)
+	}
+	return g.WordManager.IsWordComplete(platform.Word, platform.Typed)
+}
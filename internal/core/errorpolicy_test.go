@@ -0,0 +1,154 @@
+package core
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStopOnError(t *testing.T) {
+	game := newPlayingGame(t)
+	platform := &game.Platforms[game.Player.Platform]
+	word := platform.Word
+
+	game.ProcessInput(rune(word[0]))
+	game.ProcessInput('9')
+	if platform.Typed != word[:1] || game.Mistakes != 1 {
+		t.Fatalf("Expected the wrong key to be refused and counted, got %q with %d mistakes", platform.Typed, game.Mistakes)
+	}
+	if game.ErrorFlash <= 0 || !strings.Contains(game.Render(), ColorBold+ColorRed+"["+word[:1]+"]") {
+		t.Error("Expected the word to flash red after the mistake")
+	}
+	for i := 0; i < 20; i++ {
+		game.Render()
+	}
+	if game.ErrorFlash > 0 {
+		t.Errorf("Expected the flash to be over, %v left", game.ErrorFlash)
+	}
+}
+
+func TestAllowErrors(t *testing.T) {
+	game := newPlayingGame(t)
+	game.Errors = ErrorsAllow
+	platform := &game.Platforms[game.Player.Platform]
+	word := platform.Word
+
+	game.ProcessInput('9')
+	for _, ch := range word {
+		game.ProcessInput(ch)
+	}
+	if platform.Complete || platform.Typed != "9"+word[:len(word)-1] {
+		t.Fatalf("Expected the wrong key to be typed and to hold the word back, got %q", platform.Typed)
+	}
+	if !strings.Contains(game.Render(), ColorRed+"9") {
+		t.Error("Expected the wrong character to be red")
+	}
+
+	platform = &game.Platforms[game.Player.Platform] // Rendering may have moved the platforms
+	mistakes := game.Mistakes
+	game.ProcessInput(KeyCtrlBackspace)
+	if platform.Typed != "" {
+		t.Fatalf("Expected Ctrl+Backspace to clear the typed word, got %q", platform.Typed)
+	}
+	typeCurrentWord(game, false)
+	if !platform.Complete || game.Mistakes != mistakes {
+		t.Errorf("Expected the corrected word to complete without more mistakes, got %+v with %d", platform, game.Mistakes)
+	}
+}
+
+func TestAllowErrorsTypingPastMistake(t *testing.T) {
+	game := newPlayingGame(t)
+	game.Errors = ErrorsAllow
+	platform := &game.Platforms[game.Player.Platform]
+	word := platform.Word
+
+	game.ProcessInput(rune(word[0]))
+	game.ProcessInput('9')
+	for _, ch := range word[2:] {
+		game.ProcessInput(ch) // Right for their position, but after the wrong key
+	}
+	if game.CharsTyped != 1 || game.Mistakes != len(word)-1 {
+		t.Errorf("Expected only the key before the mistake to be correct, got %d chars and %d mistakes",
+			game.CharsTyped, game.Mistakes)
+	}
+	if stats := game.GetStats(); stats.Accuracy > 100.0/float64(len(word)) {
+		t.Errorf("Expected the keys after the mistake to lower the accuracy, got %.1f%%", stats.Accuracy)
+	}
+}
+
+func TestSkipErrors(t *testing.T) {
+	game := newPlayingGame(t)
+	game.Errors = ErrorsSkip
+	platform := &game.Platforms[game.Player.Platform]
+	word := platform.Word
+
+	game.ProcessInput('9')
+	for _, ch := range word[1:] {
+		game.ProcessInput(ch)
+	}
+	if !platform.Complete || game.Mistakes != 1 || game.CharsTyped != len(word)-1 {
+		t.Errorf("Expected the word to complete past the wrong key, got %+v with %d mistakes and %d chars",
+			platform, game.Mistakes, game.CharsTyped)
+	}
+	if game.Combo != 0 {
+		t.Errorf("Expected the mistake to break the combo, got %d", game.Combo)
+	}
+	if want := (len(word) - 1) * 10; game.Breakdown.Base != want {
+		t.Errorf("Expected base points for the %d right characters only, got %d", len(word)-1, game.Breakdown.Base)
+	}
+}
+
+func TestTypedErrors(t *testing.T) {
+	got := NewWordManager().typedErrors("Jump", "jxmpq")
+	want := []bool{false, true, false, false, true}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("typedErrors() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestReplayKeepsErrorPolicy(t *testing.T) {
+	game := newPlayingGame(t)
+	game.State = StateMenu
+	clock := NewManualClock(time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC))
+	game.Clock = clock
+	game.ApplySettings(RunSettings{Mode: "zen", Pack: "classic", Difficulty: DifficultyEasy, Seed: 7})
+	game.Errors = ErrorsAllow
+	game.ProcessInput(' ')
+	for i := 0; i < 2; i++ {
+		keys := "9\b" + game.Platforms[game.Player.Platform].Word
+		for _, ch := range keys {
+			clock.Advance(200 * time.Millisecond)
+			for f := 0; f < 12; f++ {
+				game.Render()
+			}
+			game.ProcessInput(ch)
+		}
+	}
+	for f := 0; f < 60; f++ {
+		clock.Advance(time.Second / 60)
+		game.Render()
+	}
+	game.ProcessInput(KeyEscape)
+	clock.Advance(time.Second)
+	game.ProcessInput(KeyEnter)
+
+	if game.LastReplay.RunErrors() != ErrorsAllow || game.WordsTyped != 2 {
+		t.Fatalf("Expected 2 words and the policy in the replay, got %d and %q", game.WordsTyped, game.LastReplay.Errors)
+	}
+	v, err := VerifyReplay(game.LastReplay)
+	if err != nil {
+		t.Fatalf("VerifyReplay() error: %v", err)
+	}
+	if !v.OK() || v.Replayed.WordsTyped != 2 {
+		t.Errorf("Expected the run to replay with its own error policy, got %v", v.Issues)
+	}
+
+	bad := *game.LastReplay
+	bad.Errors = "ignore"
+	if err := bad.Validate(); !errors.Is(err, ErrInvalidReplay) {
+		t.Errorf("Expected an unknown policy to be refused, got %v", err)
+	}
+}
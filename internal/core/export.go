@@ -0,0 +1,290 @@
+package core
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// exportSchemaVersion is bumped whenever a column is renamed, removed or
+// changes meaning. New columns are only ever appended.
+const exportSchemaVersion = 1
+
+// ExportFilter narrows down the exported statistics
+type ExportFilter struct {
+	Profiles []string  // profile names, empty for every profile
+	Mode     string    // mode ID, empty for every mode
+	From     time.Time // first instant included, zero for no lower bound
+	To       time.Time // first instant excluded, zero for no upper bound
+}
+
+// includes reports whether a run of mode at date passes the filter
+func (f ExportFilter) includes(mode string, date time.Time) bool {
+	if f.Mode != "" && mode != f.Mode {
+		return false
+	}
+	if !f.From.IsZero() && date.Before(f.From) {
+		return false
+	}
+	return f.To.IsZero() || date.Before(f.To)
+}
+
+// SessionRecord is an exported row of the session history
+type SessionRecord struct {
+	Profile         string  `json:"profile"`
+	Date            string  `json:"date"` // RFC 3339
+	Mode            string  `json:"mode"`
+	Pack            string  `json:"pack"`
+	Difficulty      int     `json:"difficulty"`
+	Seed            int64   `json:"seed"`
+	Lives           int     `json:"lives"`
+	Daily           bool    `json:"daily"`
+	Reason          string  `json:"reason"`
+	Score           int     `json:"score"`
+	WPM             float64 `json:"wpm"`
+	CPM             float64 `json:"cpm"`
+	Accuracy        float64 `json:"accuracy"`
+	Words           int     `json:"words"`
+	Chars           int     `json:"chars"`
+	DurationSeconds float64 `json:"duration_seconds"`
+}
+
+// KeyRecord is an exported row of the per-key analytics
+type KeyRecord struct {
+	Profile          string  `json:"profile"`
+	Key              string  `json:"key"`
+	Hits             int     `json:"hits"`
+	Misses           int     `json:"misses"`
+	Accuracy         float64 `json:"accuracy"`
+	AvgLatencyMillis float64 `json:"avg_latency_ms"`
+}
+
+// HighScoreRecord is an exported row of the high score tables
+type HighScoreRecord struct {
+	Profile         string  `json:"profile"`
+	Mode            string  `json:"mode"`
+	Rank            int     `json:"rank"`
+	Date            string  `json:"date"` // RFC 3339
+	Score           int     `json:"score"`
+	WPM             float64 `json:"wpm"`
+	Accuracy        float64 `json:"accuracy"`
+	Words           int     `json:"words"`
+	DurationSeconds float64 `json:"duration_seconds"`
+	Replay          string  `json:"replay"`
+	Preset          string  `json:"preset"` // difficulty preset ID, empty for scores from before presets
+}
+
+// StatsExport is everything written by a statistics export
+type StatsExport struct {
+	SchemaVersion int               `json:"schema_version"`
+	Exported      string            `json:"exported"` // RFC 3339
+	Sessions      []SessionRecord   `json:"sessions"`
+	Keys          []KeyRecord       `json:"keys"`
+	HighScores    []HighScoreRecord `json:"high_scores"`
+}
+
+// CollectStats gathers the statistics of the profiles in store that pass
+// filter. Key analytics have no dates or modes and are only filtered by
+// profile.
+func CollectStats(store *ProfileStore, filter ExportFilter, now time.Time) (*StatsExport, error) {
+	names := filter.Profiles
+	if len(names) == 0 {
+		names = store.Profiles
+	}
+	export := &StatsExport{
+		SchemaVersion: exportSchemaVersion,
+		Exported:      now.Format(time.RFC3339),
+		Sessions:      []SessionRecord{},
+		Keys:          []KeyRecord{},
+		HighScores:    []HighScoreRecord{},
+	}
+	for _, name := range names {
+		profile, err := store.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		export.addProfile(profile, filter)
+	}
+	return export, nil
+}
+
+// addProfile appends the records of a profile
+func (e *StatsExport) addProfile(profile *Profile, filter ExportFilter) {
+	for _, s := range profile.History.Sessions {
+		if !filter.includes(s.Settings.Mode, s.Date) {
+			continue
+		}
+		e.Sessions = append(e.Sessions, SessionRecord{
+			Profile:         profile.Name,
+			Date:            s.Date.Format(time.RFC3339),
+			Mode:            s.Settings.Mode,
+			Pack:            s.Settings.Pack,
+			Difficulty:      s.Settings.Difficulty,
+			Seed:            s.Settings.Seed,
+			Lives:           s.Lives,
+			Daily:           s.Daily,
+			Reason:          s.Reason,
+			Score:           s.Stats.Score,
+			WPM:             s.Stats.WPM,
+			CPM:             s.Stats.CPM,
+			Accuracy:        s.Stats.Accuracy,
+			Words:           s.Stats.WordsTyped,
+			Chars:           s.Stats.CharsTyped,
+			DurationSeconds: s.Stats.GameTime.Seconds(),
+		})
+	}
+
+	keys := make([]string, 0, len(profile.Keys.Keys))
+	for key := range profile.Keys.Keys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		stat := profile.Keys.Keys[key]
+		e.Keys = append(e.Keys, KeyRecord{
+			Profile:          profile.Name,
+			Key:              key,
+			Hits:             stat.Hits,
+			Misses:           stat.Misses,
+			Accuracy:         stat.Accuracy(),
+			AvgLatencyMillis: float64(stat.AverageLatency()) / float64(time.Millisecond),
+		})
+	}
+
+	for _, mode := range gameModes {
+		for i, entry := range profile.HighScores.Top(mode) {
+			if !filter.includes(mode.ID(), entry.Date) {
+				continue
+			}
+			e.HighScores = append(e.HighScores, HighScoreRecord{
+				Profile:         profile.Name,
+				Mode:            mode.ID(),
+				Rank:            i + 1,
+				Date:            entry.Date.Format(time.RFC3339),
+				Score:           entry.Score,
+				WPM:             entry.WPM,
+				Accuracy:        entry.Accuracy,
+				Words:           entry.Words,
+				DurationSeconds: entry.Duration.Seconds(),
+				Replay:          entry.Replay,
+				Preset:          entry.Preset,
+			})
+		}
+	}
+}
+
+// WriteJSON writes the export as a single JSON document
+func (e *StatsExport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(e)
+}
+
+// Column headers of the CSV files, in the order of the JSON fields
+var (
+	sessionColumns = []string{"profile", "date", "mode", "pack", "difficulty", "seed", "lives", "daily",
+		"reason", "score", "wpm", "cpm", "accuracy", "words", "chars", "duration_seconds"}
+	keyColumns       = []string{"profile", "key", "hits", "misses", "accuracy", "avg_latency_ms"}
+	highScoreColumns = []string{"profile", "mode", "rank", "date", "score", "wpm", "accuracy", "words",
+		"duration_seconds", "replay", "preset"}
+)
+
+// WriteCSV writes sessions.csv, keys.csv and highscores.csv to dir
+func (e *StatsExport) WriteCSV(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	sessions := make([][]string, len(e.Sessions))
+	for i, s := range e.Sessions {
+		sessions[i] = []string{s.Profile, s.Date, s.Mode, s.Pack, itoa(s.Difficulty),
+			strconv.FormatInt(s.Seed, 10), itoa(s.Lives), strconv.FormatBool(s.Daily), s.Reason,
+			itoa(s.Score), ftoa(s.WPM), ftoa(s.CPM), ftoa(s.Accuracy), itoa(s.Words), itoa(s.Chars),
+			ftoa(s.DurationSeconds)}
+	}
+	keys := make([][]string, len(e.Keys))
+	for i, k := range e.Keys {
+		keys[i] = []string{k.Profile, k.Key, itoa(k.Hits), itoa(k.Misses), ftoa(k.Accuracy), ftoa(k.AvgLatencyMillis)}
+	}
+	highScores := make([][]string, len(e.HighScores))
+	for i, h := range e.HighScores {
+		highScores[i] = []string{h.Profile, h.Mode, itoa(h.Rank), h.Date, itoa(h.Score), ftoa(h.WPM),
+			ftoa(h.Accuracy), itoa(h.Words), ftoa(h.DurationSeconds), h.Replay, h.Preset}
+	}
+
+	tables := []struct {
+		file    string
+		columns []string
+		rows    [][]string
+	}{
+		{"sessions.csv", sessionColumns, sessions},
+		{"keys.csv", keyColumns, keys},
+		{"highscores.csv", highScoreColumns, highScores},
+	}
+	for _, table := range tables {
+		if err := writeCSVFile(filepath.Join(dir, table.file), table.columns, table.rows); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeCSVFile(path string, columns []string, rows [][]string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(file)
+	w.Write(columns)
+	w.WriteAll(rows) // Flushes and reports the first write error
+	if err := w.Error(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func itoa(n int) string {
+	return strconv.Itoa(n)
+}
+
+// ftoa formats stats with two decimals, plenty for spreadsheets
+func ftoa(f float64) string {
+	return strconv.FormatFloat(f, 'f', 2, 64)
+}
+
+// exportProgress exports the statistics shown on the progress screen: the
+// active profile, filtered by the charted mode, as CSV and JSON
+func (g *Game) exportProgress() {
+	if g.Profiles == nil || g.Profile == nil {
+		return
+	}
+	filter := ExportFilter{Profiles: []string{g.Profile.Name}, Mode: g.progressMode}
+	export, err := CollectStats(g.Profiles, filter, g.now())
+	if err != nil {
+		g.MenuMessage = g.tr("Export failed: %s", err)
+		return
+	}
+	dir := "stats-" + profileSlug(g.Profile.Name)
+	if err := export.WriteCSV(dir); err != nil {
+		g.MenuMessage = g.tr("Export failed: %s", err)
+		return
+	}
+	file, err := os.Create(filepath.Join(dir, "stats.json"))
+	if err == nil {
+		err = export.WriteJSON(file)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		g.MenuMessage = g.tr("Export failed: %s", err)
+		return
+	}
+	g.MenuMessage = g.tr("Exported to %s", dir)
+}
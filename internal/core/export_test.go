@@ -0,0 +1,130 @@
+package core
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// exportStore returns a store with two profiles that each played a sprint
+// and a zen run a day apart
+func exportStore(t *testing.T, day time.Time) *ProfileStore {
+	t.Helper()
+	store, err := LoadProfiles(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadProfiles() error: %v", err)
+	}
+	if err := store.Create("Bob"); err != nil {
+		t.Fatalf("Create() error: %v", err)
+	}
+	for _, name := range store.Profiles {
+		profile, err := store.Open(name)
+		if err != nil {
+			t.Fatalf("Open() error: %v", err)
+		}
+		profile.History.Append(Session{Date: day, Settings: RunSettings{Mode: "sprint60"}, Stats: Stats{Score: 100, WPM: 40}})
+		profile.History.Append(Session{Date: day.AddDate(0, 0, 1), Settings: RunSettings{Mode: "zen"}, Stats: Stats{WPM: 50}})
+		profile.HighScores.Add(gameModes[1], HighScore{Score: 100, Date: day})
+		profile.HighScores.Save()
+		profile.Keys.Record('e', true, 0)
+		profile.Keys.Save()
+	}
+	return store
+}
+
+func TestCollectStatsFilters(t *testing.T) {
+	day := time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC)
+	store := exportStore(t, day)
+
+	all, err := CollectStats(store, ExportFilter{}, day)
+	if err != nil {
+		t.Fatalf("CollectStats() error: %v", err)
+	}
+	if len(all.Sessions) != 4 || len(all.Keys) != 2 || len(all.HighScores) != 2 {
+		t.Errorf("Expected everything of both profiles, got %d sessions, %d keys, %d high scores",
+			len(all.Sessions), len(all.Keys), len(all.HighScores))
+	}
+
+	tests := []struct {
+		name     string
+		filter   ExportFilter
+		sessions int
+	}{
+		{"profile", ExportFilter{Profiles: []string{"Bob"}}, 2},
+		{"mode", ExportFilter{Mode: "zen"}, 2},
+		{"from", ExportFilter{From: day.AddDate(0, 0, 1)}, 2},
+		{"to", ExportFilter{To: day.AddDate(0, 0, 1)}, 2},
+		{"everything", ExportFilter{Profiles: []string{"Bob"}, Mode: "sprint60", To: day.AddDate(0, 0, 1)}, 1},
+	}
+	for _, tt := range tests {
+		export, err := CollectStats(store, tt.filter, day)
+		if err != nil {
+			t.Fatalf("%s: CollectStats() error: %v", tt.name, err)
+		}
+		if len(export.Sessions) != tt.sessions {
+			t.Errorf("%s: expected %d sessions, got %d", tt.name, tt.sessions, len(export.Sessions))
+		}
+	}
+
+	if _, err := CollectStats(store, ExportFilter{Profiles: []string{"Nobody"}}, day); err == nil {
+		t.Error("Expected an error for an unknown profile")
+	}
+}
+
+func TestStatsExportFormats(t *testing.T) {
+	day := time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC)
+	export, err := CollectStats(exportStore(t, day), ExportFilter{Profiles: []string{"Bob"}}, day)
+	if err != nil {
+		t.Fatalf("CollectStats() error: %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := export.WriteCSV(dir); err != nil {
+		t.Fatalf("WriteCSV() error: %v", err)
+	}
+	for file, columns := range map[string][]string{
+		"sessions.csv":   sessionColumns,
+		"keys.csv":       keyColumns,
+		"highscores.csv": highScoreColumns,
+	} {
+		f, err := os.Open(filepath.Join(dir, file))
+		if err != nil {
+			t.Fatalf("Open(%s) error: %v", file, err)
+		}
+		rows, err := csv.NewReader(f).ReadAll()
+		f.Close()
+		if err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+		if !reflect.DeepEqual(rows[0], columns) {
+			t.Errorf("%s: expected header %v, got %v", file, columns, rows[0])
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := export.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON() error: %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+	if decoded["schema_version"] != float64(exportSchemaVersion) {
+		t.Errorf("Expected schema version %d, got %v", exportSchemaVersion, decoded["schema_version"])
+	}
+	// The JSON fields and the CSV columns describe the same schema
+	session := decoded["sessions"].([]any)[0].(map[string]any)
+	if len(session) != len(sessionColumns) {
+		t.Errorf("Expected %d session fields, got %d", len(sessionColumns), len(session))
+	}
+	for _, column := range sessionColumns {
+		if _, ok := session[column]; !ok {
+			t.Errorf("Expected JSON field %q", column)
+		}
+	}
+}
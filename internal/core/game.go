@@ -1,15 +1,18 @@
 package core
 
 import (
-	"math"
 	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
 const (
-	platformSpacing        = 10   // spacing between platforms
+	platformSpacing        = 10   // spacing between platforms of the Normal preset
 	initialScrollSpeed     = 5.0  // initial scroll speed in pixels per second
 	speedIncreaseFactor    = 1.05 // factor by which speed increases after each word
 	speedIncreaseThreshold = 5    // increase speed every 5 words typed
+	courseWidth            = 80   // width platform positions are drawn on, whatever the screen
+	highScoresFile         = "highscores.json"
 )
 
 // NewGame creates a new game instance with logging to the specified file
@@ -19,16 +22,67 @@ func NewGame(logsPath string) (*Game, error) {
 	if err != nil {
 		return nil, err
 	}
+	return newGameWithFiles(logger), nil
+}
+
+// NewPlaybackGame creates a game for playing replays back. Unlike NewGame it
+// loads none of the player's high scores, daily history or saved run.
+func NewPlaybackGame(logsPath string) (*Game, error) {
+	logger, err := NewLogger(logsPath)
+	if err != nil {
+		return nil, err
+	}
+	return newGame(logger), nil
+}
+
+// newGameWithFiles creates a game with the high scores, daily history and
+// saved run found in the working directory
+func newGameWithFiles(logger *Logger) *Game {
 	logger.Println("NewGame: initializing game")
-	game := &Game{
+	highScores, err := LoadHighScores(highScoresFile)
+	if err != nil {
+		logger.Printf("NewGame: failed to load high scores: %v", err)
+	}
+	dailyHistory, err := LoadDailyHistory(dailyHistoryFile)
+	if err != nil {
+		logger.Printf("NewGame: failed to load daily history: %v", err)
+	}
+	game := newGame(logger)
+	game.HighScores = highScores
+	game.DailyHistory = dailyHistory
+	game.SaveFile = saveFile
+	game.SavedRun, err = LoadSaveGame(saveFile)
+	if err != nil {
+		logger.Printf("NewGame: failed to load saved run: %v", err)
+		game.MenuMessage = game.tr("The saved run can't be continued: %s", err)
+	}
+	logger.Println("NewGame: game struct created")
+	return game
+}
+
+// newGame creates a game that doesn't touch any files, e.g. for ghosts
+func newGame(logger *Logger) *Game {
+	g := &Game{
 		State:       StateMenu,
-		ScrollSpeed: initialScrollSpeed, // pixels per second - increased for visible scrolling. default to 5.0
+		ScrollSpeed: initialScrollSpeed,               // pixels per second - increased for visible scrolling. default to 5.0
+		Tuning:      presets[DifficultyEasy-1].Tuning, // the preset of NewWordManager
+		Bindings:    DefaultBindings(),
+		Errors:      ErrorsStop,
+		Theme:       themes[0],
+		Locale:      locales[0],
+		Layout:      layouts[0],
 		WordManager: NewWordManager(),
 		ShouldExit:  false,
+		Mode:        gameModes[0],
 		Logger:      logger,
-	}
-	logger.Println("NewGame: game struct created")
-	return game, nil
+		Clock:       systemClock{},
+		rng:         newSeededRand(time.Now().UnixNano()),
+	}
+	g.mainMenu = g.newMainMenu()
+	g.settingsMenu = g.newSettingsMenu()
+	g.lessonsMenu = g.newLessonsMenu()
+	g.profilesMenu = g.newProfilesMenu()
+	return g
 }
 
 // Start initializes the game with given dimensions
@@ -43,6 +97,8 @@ func (g *Game) Start(width, height int) {
 // UpdateDimensions updates the game dimensions
 func (g *Game) UpdateDimensions(width, height int) {
 	g.Logger.Printf("UpdateDimensions: width=%d, height=%d", width, height)
+	defer g.freezeTime()()
+	g.recordEvent(ReplayEvent{Width: width, Height: height})
 	g.Width = width
 	g.Height = height
 	if g.Renderer != nil {
@@ -52,7 +108,9 @@ func (g *Game) UpdateDimensions(width, height int) {
 
 // ProcessInput handles user input
 func (g *Game) ProcessInput(key rune) {
-	g.Logger.Printf("ProcessInput: key=%v, state=%v", key, g.State)
+	g.Logger.Debugf("ProcessInput: key=%v, state=%v", key, g.State)
+	defer g.freezeTime()()
+	g.recordEvent(ReplayEvent{Key: key})
 	switch g.State {
 	case StateMenu:
 		g.processMenuInput(key)
@@ -62,15 +120,25 @@ func (g *Game) ProcessInput(key rune) {
 		g.processPauseInput(key)
 	case StateGameOver:
 		g.processGameOverInput(key)
+	case StateDailyHistory:
+		g.processDailyHistoryInput(key)
+	case StateProfiles:
+		g.processProfilesInput(key)
+	case StateProgress:
+		g.processProgressInput(key)
+	case StateSettings:
+		g.processSettingsInput(key)
+	case StateKeyboard:
+		g.processKeyboardInput(key)
+	case StateLessons:
+		g.processLessonsInput(key)
 	}
 }
 
 // Render updates game logic and returns the rendered frame
 func (g *Game) Render() string {
 	// g.Logger.Printf("Render: state=%v", g.State)
-	if g.State == StatePlaying {
-		g.updateGameLogic()
-	}
+	g.step()
 
 	if g.Renderer != nil {
 		return g.Renderer.RenderGame(g)
@@ -85,14 +153,46 @@ func (g *Game) ShouldQuit() bool {
 
 // Private methods
 
+// step advances the game by one frame
+func (g *Game) step() {
+	defer g.freezeTime()()
+	g.frame++
+	if g.State == StatePlaying {
+		g.updateGameLogic()
+		g.stepGhost()
+	}
+}
+
 func (g *Game) reset() {
 	g.Logger.Println("reset: resetting game state")
 	g.Score = 0
 	g.WordsTyped = 0
 	g.CharsTyped = 0
-	g.StartTime = time.Now()
+	g.Mistakes = 0
+	g.Combo = 0
+	g.BestCombo = 0
+	g.Breakdown = ScoreBreakdown{}
+	g.Callout = ""
+	g.CalloutTimer = 0
+	g.ErrorFlash = 0
+	g.Lives = g.StartingLives
+	g.Invulnerable = 0
+	g.platformCount = 0
+	g.Effects = nil
+	g.Shields = 0
+	g.StartTime = g.now()
+	g.ActiveTime = 0
+	g.Timeline = nil
+	g.PausedTime = 0
+	g.EndReason = ""
+	g.HighScoreRank = 0
+	g.DailyRecorded = false
+	g.LessonResult = nil
 	g.ScrollOffset = 0
+	g.ScrollSpeed = g.Tuning.Speed.Start
 	g.ScrollAccumulator = 0 // Reset scroll accumulator
+	g.frame = 0
+	g.seedRun()
 	g.Player = Player{
 		X:        g.Width / 2,
 		Y:        g.Height/4 - 1, // Position player on the starting platform in upper portion
@@ -101,120 +201,254 @@ func (g *Game) reset() {
 
 	// Initialize platforms
 	g.generateInitialPlatforms()
+	g.activateWord()
+
+	g.recording = nil
+	g.ghost = nil
+	if g.State == StatePlaying {
+		g.startRecording()
+		g.startGhost()
+	}
 }
 
 func (g *Game) processMenuInput(key rune) {
-	g.Logger.Printf("processMenuInput: key=%v", key)
-	switch key {
-	case ' ': // Space to start
-		g.State = StatePlaying
-		g.reset()
-	case 'q', 'Q':
-		g.ShouldExit = true
-	case 27: // ESC
+	g.Logger.Debugf("processMenuInput: key=%v", key)
+	if g.codeEntry {
+		g.processCodeInput(key)
+		return
+	}
+	g.MenuMessage = ""
+	switch {
+	case g.Bindings.Is(ActionStart, key):
+		g.startRun()
+		return
+	case g.Bindings.Is(ActionQuit, key):
 		g.ShouldExit = true
+		return
+	case g.mainMenu.ProcessInput(key):
+		return
+	}
+	switch key {
+	case 'm', 'M': // Cycle through game modes
+		g.Mode = nextMode(g.Mode)
+		g.ClearGhost()
+		g.Logger.Printf("processMenuInput: mode changed to %s", g.Mode.ID())
+		g.saveProfileSettings()
+	case 'l', 'L': // Cycle through lives options
+		g.StartingLives = nextLivesOption(g.StartingLives)
+		g.ClearGhost()
+		g.Logger.Printf("processMenuInput: starting lives changed to %d", g.StartingLives)
+		g.saveProfileSettings()
+	case 'p', 'P': // Cycle through word packs
+		g.WordManager.SetPack(nextWordPack(g.WordManager.Pack))
+		g.ClearGhost()
+		g.Logger.Printf("processMenuInput: word pack changed to %s", g.WordManager.Pack)
+		g.saveProfileSettings()
+	case 'd', 'D': // Cycle through difficulty levels
+		g.SetDifficulty(g.WordManager.Difficulty%len(presets) + 1)
+		g.ClearGhost()
+		g.Logger.Printf("processMenuInput: difficulty changed to %d", g.WordManager.Difficulty)
+		g.saveProfileSettings()
+	}
+}
+
+// newMainMenu creates the actions of the main menu. The run settings are
+// changed with their letter shortcuts, shown above the menu.
+func (g *Game) newMainMenu() *Menu {
+	return &Menu{
+		Items: []MenuItem{
+			{Label: "Continue saved run", Run: func(int) { g.resumeRun() },
+				Hidden: func() bool { return g.SavedRun == nil }},
+			{Label: "Start", Shortcut: g.Bindings.Key(ActionStart), Run: func(int) { g.startRun() }},
+			{Label: "Daily challenge", Shortcut: 'y', Run: func(int) { g.ClearGhost(); g.startDaily() }},
+			{Label: "Race your best", Shortcut: 'g', Run: func(int) { g.toggleBestGhost() }},
+			{Label: "Enter challenge code", Shortcut: 'c', Run: func(int) { g.codeEntry, g.codeInput = true, "" }},
+			{Label: "Random course", Shortcut: 'r', Run: func(int) { g.FixedSeed = false; g.ClearGhost() }},
+			{Label: "Daily history", Shortcut: 'h', Run: func(int) { g.State = StateDailyHistory }},
+			{Label: "Progress", Shortcut: 's', Run: func(int) { g.State = StateProgress },
+				Hidden: func() bool { return g.History == nil }},
+			{Label: "Keyboard and drills", Shortcut: 'k', Run: func(int) { g.State = StateKeyboard }},
+			{Label: "Lessons", Shortcut: 'e', Run: func(int) { g.openLessons() }},
+			{Label: "Profiles", Shortcut: 'u', Run: func(int) { g.openProfiles() },
+				Hidden: func() bool { return g.Profiles == nil }},
+			{Label: "Settings", Shortcut: 'o', Run: func(int) { g.State = StateSettings }},
+			{Label: "Quit", Shortcut: g.Bindings.Key(ActionQuit), Run: func(int) { g.ShouldExit = true }},
+		},
+		Back: func() { g.ShouldExit = true },
 	}
 }
 
+// startRun starts a new run of the selected settings
+func (g *Game) startRun() {
+	g.leaveDaily()
+	g.State = StatePlaying
+	g.reset()
+}
+
 func (g *Game) processGameInput(key rune) {
-	g.Logger.Printf("processGameInput: key=%v", key)
-	switch key {
-	case 27: // ESC - pause
+	g.Logger.Debugf("processGameInput: key=%v", key)
+	switch {
+	case g.Bindings.Is(ActionPause, key):
 		g.State = StatePaused
-	case 8, 127: // Backspace
+		g.pausedAt = g.now()
+	case g.Bindings.Is(ActionDeleteChar, key):
 		g.handleBackspace()
-	default:
-		if isAlphanumeric(key) {
-			g.handleTyping(key)
-		}
+	case g.Bindings.Is(ActionDeleteWord, key):
+		g.deleteWord()
+	case g.Bindings.Is(ActionSkipWord, key):
+		g.swapWord()
+	case isTypable(key):
+		g.handleTyping(key)
 	}
 }
 
 func (g *Game) processPauseInput(key rune) {
-	g.Logger.Printf("processPauseInput: key=%v", key)
-	switch key {
-	case 27: // ESC - resume
+	g.Logger.Debugf("processPauseInput: key=%v", key)
+	switch {
+	case g.Bindings.Is(ActionResume, key):
 		g.State = StatePlaying
-	case 'q', 'Q':
+		g.PausedTime += g.now().Sub(g.pausedAt)
+	case key == '\r' || key == '\n': // Enter - end the run and show the results
+		g.PausedTime += g.now().Sub(g.pausedAt)
+		g.endRun("Run ended")
+	case key == 's' || key == 'S': // Save the run and go back to the menu
+		g.saveRun()
+		g.leaveDaily()
+		g.State = StateMenu
+	case g.Bindings.Is(ActionQuit, key): // Save the run and quit
+		g.saveRun()
 		g.ShouldExit = true
 	}
 }
 
 func (g *Game) processGameOverInput(key rune) {
-	g.Logger.Printf("processGameOverInput: key=%v", key)
-	switch key {
-	case ' ': // Space to restart
+	g.Logger.Debugf("processGameOverInput: key=%v", key)
+	switch {
+	case g.Bindings.Is(ActionStart, key): // Play again
 		g.State = StatePlaying
 		g.reset()
-	case 'q', 'Q':
+	case g.Bindings.Is(ActionRestart, key): // Retry the same course
+		g.FixedSeed = true
+		g.State = StatePlaying
+		g.reset()
+	case key == 't' || key == 'T': // Switch between the results and the timeline chart
+		g.showTimeline = !g.showTimeline
+	case key == 'm' || key == 'M': // Back to the menu
+		g.leaveDaily()
+		g.State = StateMenu
+	case g.Bindings.Is(ActionQuit, key):
 		g.ShouldExit = true
 	}
 }
 
+func (g *Game) processDailyHistoryInput(key rune) {
+	g.Logger.Debugf("processDailyHistoryInput: key=%v", key)
+	g.State = StateMenu // Any key goes back to the menu
+}
+
 func (g *Game) handleTyping(key rune) {
-	g.Logger.Printf("handleTyping: key=%v", key)
+	g.Logger.Debugf("handleTyping: key=%v", key)
 	if len(g.Platforms) == 0 {
 		return
 	}
 
 	currentPlatform := &g.Platforms[g.Player.Platform]
+	if currentPlatform.Complete {
+		return // Waiting for the next platform to appear
+	}
 
 	// Check if the character is correct
-	if g.WordManager.IsValidChar(currentPlatform.Word, currentPlatform.Typed, key) {
-		currentPlatform.Typed += string(key)
+	correct := g.keyCorrect(currentPlatform, key)
+	g.recordKey(currentPlatform, correct)
+	complete := g.typeKey(currentPlatform, key, correct)
+	if correct {
 		g.CharsTyped++
-
-		// Check if word is complete
-		if g.WordManager.IsWordComplete(currentPlatform.Word, currentPlatform.Typed) {
-			g.completeWord(currentPlatform)
-		}
+	} else {
+		g.registerMistake()
+		g.ErrorFlash = errorFlashTime
+		g.Mode.OnMistake(g)
+	}
+	if complete && g.State == StatePlaying {
+		g.completeWord(currentPlatform)
 	}
 }
 
 func (g *Game) handleBackspace() {
-	g.Logger.Println("handleBackspace")
+	g.Logger.Debugf("handleBackspace")
 	if len(g.Platforms) == 0 {
 		return
 	}
 
 	currentPlatform := &g.Platforms[g.Player.Platform]
-	if len(currentPlatform.Typed) > 0 {
-		currentPlatform.Typed = currentPlatform.Typed[:len(currentPlatform.Typed)-1]
+	_, size := utf8.DecodeLastRuneInString(currentPlatform.Typed)
+	currentPlatform.Typed = currentPlatform.Typed[:len(currentPlatform.Typed)-size]
+}
+
+// deleteWord clears everything typed of the current word
+func (g *Game) deleteWord() {
+	g.Logger.Debugf("deleteWord")
+	if len(g.Platforms) == 0 {
+		return
 	}
+	g.Platforms[g.Player.Platform].Typed = ""
+}
+
+// swapWord skips the current word by swapping it for another one. It breaks
+// the combo like a mistake, but isn't counted as one.
+func (g *Game) swapWord() {
+	if len(g.Platforms) == 0 {
+		return
+	}
+	platform := &g.Platforms[g.Player.Platform]
+	if platform.Complete {
+		return
+	}
+	platform.Word = g.WordManager.GetRandomWord()
+	platform.Typed = ""
+	g.Combo = 0
+	g.Logger.Debugf("swapWord: new word=%s", platform.Word)
 }
 
 func (g *Game) completeWord(platform *Platform) {
-	g.Logger.Printf("completeWord: word=%s", platform.Word)
+	g.Logger.Debugf("completeWord: word=%s", platform.Word)
 	platform.Complete = true
 	g.WordsTyped++
-	g.Score += len(platform.Word) * 10 // Base score
-
-	// Bonus for speed - reward faster typing
-	timeSinceStart := time.Since(g.StartTime).Seconds()
-	if timeSinceStart > 0 {
-		speedBonus := int(math.Max(0, 100-(timeSinceStart/float64(g.WordsTyped))))
-		g.Score += speedBonus
+	g.registerWord()
+	breakdown := g.Mode.WordScore(g, platform)
+	if g.EffectActive(PowerUpDouble) {
+		breakdown.PowerUp = breakdown.Total()
+	}
+	g.Breakdown.Add(breakdown)
+	g.Score += breakdown.Total()
+	if platform.Checkpoint {
+		g.reachCheckpoint()
 	}
 
-	// Increase scroll speed every speedIncreaseThreshold words - progressive difficulty
-	if g.WordsTyped%speedIncreaseThreshold == 0 {
+	// Increase scroll speed every Speed.Interval words, up to Speed.Max - progressive difficulty
+	speed := g.Tuning.Speed
+	if g.WordsTyped%speed.Interval == 0 && !g.EffectActive(PowerUpFreeze) {
 		oldSpeed := g.ScrollSpeed
-		g.ScrollSpeed *= speedIncreaseFactor
+		g.ScrollSpeed *= speed.Factor
+		if speed.Max > 0 {
+			g.ScrollSpeed = min(g.ScrollSpeed, max(speed.Max, oldSpeed))
+		}
 		g.Logger.Printf("Speed increased from %.2f to %.2f after %d words", oldSpeed, g.ScrollSpeed, g.WordsTyped)
 	}
 
-	// Move player to next platform
+	// Move player to next platform, then grant the platform's power-up
+	powerUp := platform.PowerUp
 	g.jumpToNextPlatform()
+	g.applyPowerUp(powerUp)
 }
 
 func (g *Game) jumpToNextPlatform() {
-	g.Logger.Println("jumpToNextPlatform")
+	g.Logger.Debugf("jumpToNextPlatform")
 	// Find next available platform above current one
 	currentY := g.Platforms[g.Player.Platform].Y
 	nextPlatformIndex := -1
 
 	for i, platform := range g.Platforms {
-		if platform.Y < currentY && !platform.Complete {
+		if platform.Y < currentY && !platform.Complete && platform.Standable() {
 			if nextPlatformIndex == -1 || platform.Y > g.Platforms[nextPlatformIndex].Y {
 				nextPlatformIndex = i
 			}
@@ -226,6 +460,7 @@ func (g *Game) jumpToNextPlatform() {
 		platform := g.Platforms[nextPlatformIndex]
 		g.Player.X = platform.X + platform.Width/2
 		g.Player.Y = platform.Y - 1
+		g.activateWord()
 	} else {
 		// Generate new platforms if needed
 		g.generateMorePlatforms()
@@ -236,16 +471,38 @@ func (g *Game) updateGameLogic() {
 	// g.Logger.Println("updateGameLogic")
 	// Calculate scroll movement - platforms scroll down, creating upward movement effect
 	deltaTime := 1.0 / 60.0 // Assume 60 FPS
-	scrollDelta := g.ScrollSpeed * deltaTime
-
-	// Accumulate fractional scroll amounts - this ensures smooth scrolling at any speed
-	g.ScrollAccumulator += scrollDelta
+	dt := time.Duration(deltaTime * float64(time.Second))
+	g.ActiveTime += dt
+	g.sampleTimeline()
+	if g.Invulnerable > 0 {
+		g.Invulnerable -= dt
+	}
+	if g.CalloutTimer > 0 {
+		g.CalloutTimer -= dt
+	}
+	if g.ErrorFlash > 0 {
+		g.ErrorFlash -= dt
+	}
+	g.tickEffects(dt)
 
-	// Only move platforms when we have accumulated at least 1 full pixel
 	pixelMovement := 0
-	if g.ScrollAccumulator >= 1.0 {
-		pixelMovement = int(g.ScrollAccumulator)
-		g.ScrollAccumulator -= float64(pixelMovement) // Keep the fractional remainder
+	if g.Mode.Scrolls() {
+		scrollDelta := g.ScrollSpeed * deltaTime
+		if g.EffectActive(PowerUpSlow) {
+			scrollDelta *= slowFactor
+		}
+
+		// Accumulate fractional scroll amounts - this ensures smooth scrolling at any speed
+		g.ScrollAccumulator += scrollDelta
+
+		// Only move platforms when we have accumulated at least 1 full pixel
+		if g.ScrollAccumulator >= 1.0 {
+			pixelMovement = int(g.ScrollAccumulator)
+			g.ScrollAccumulator -= float64(pixelMovement) // Keep the fractional remainder
+		}
+	} else if len(g.Platforms) > 0 && g.Platforms[g.Player.Platform].Y < g.Height/2 {
+		// Without scrolling the view follows the player instead, one row per frame
+		pixelMovement = 1
 	}
 
 	// Debug: Log the scroll values
@@ -263,17 +520,29 @@ func (g *Game) updateGameLogic() {
 		}
 	}
 
+	// Run the per-type platform hooks (movers, crumbling and melting platforms)
+	g.updatePlatforms(dt)
+
 	// Update player position to match current platform movement
+	standing := true
 	if len(g.Platforms) > 0 && g.Player.Platform < len(g.Platforms) {
 		platform := g.Platforms[g.Player.Platform]
 		g.Player.X = platform.X + platform.Width/2
 		g.Player.Y = platform.Y - 1 // Player sits on top of platform
+		standing = platform.Standable()
 	}
 
-	// Check if player fell off screen (now using direct Y position)
-	if g.Player.Y >= g.Height-3 {
-		g.Logger.Println("updateGameLogic: player fell off screen, game over")
-		g.State = StateGameOver
+	// Check if player fell off screen (now using direct Y position) or lost their footing
+	if g.Player.Y >= g.Height-3 || !standing {
+		g.handleFall()
+		if g.State != StatePlaying {
+			return
+		}
+	}
+
+	// Let the mode end the run on its own terms
+	if over, reason := g.Mode.IsOver(g); over {
+		g.endRun(reason)
 		return
 	}
 
@@ -284,41 +553,103 @@ func (g *Game) updateGameLogic() {
 	g.cleanupPlatforms()
 }
 
+// endRun finishes the current run and records it in the high score table
+func (g *Game) endRun(reason string) {
+	g.Logger.Printf("endRun: mode=%s reason=%s", g.Mode.ID(), reason)
+	g.State = StateGameOver
+	g.EndReason = reason
+	g.HighScoreRank = 0
+	g.endedAt = g.now()
+	g.showTimeline = false
+	g.finishTimeline()
+	g.finishRecording()
+	g.recordDaily()
+	g.recordSession()
+	g.gradeLesson()
+	g.saveKeyStats()
+
+	if g.HighScores == nil || !g.Mode.Qualifies(g) {
+		return
+	}
+	stats := g.GetStats()
+	g.HighScoreRank = g.HighScores.Add(g.Mode, HighScore{
+		Score:    stats.Score,
+		WPM:      stats.WPM,
+		Accuracy: stats.Accuracy,
+		Words:    stats.WordsTyped,
+		Duration: stats.GameTime,
+		Date:     g.now(),
+		Replay:   g.LastReplayPath,
+		Preset:   g.Preset().ID,
+	})
+	if err := g.HighScores.Save(); err != nil {
+		g.Logger.Printf("endRun: failed to save high scores: %v", err)
+	}
+}
+
 func (g *Game) generateInitialPlatforms() {
-	g.Logger.Println("generateInitialPlatforms")
+	g.Logger.Debugf("generateInitialPlatforms")
 	g.Platforms = make([]Platform, 0)
 
 	// Generate starting platform near the top but with room for upward progression
-	startPlatform := Platform{
-		X:        g.Width/2 - 10,
-		Y:        g.Height / 4, // Start in upper portion of screen
-		Width:    20,
-		Word:     g.WordManager.GetRandomWord(),
-		Typed:    "",
-		Complete: false,
-	}
+	startPlatform := g.newPlatform(g.Width/2-10, g.Height/4, 20) // Start in upper portion of screen
 	g.Platforms = append(g.Platforms, startPlatform)
 
 	// Generate only a few initial platforms to start - more will be generated dynamically
 	currentY := startPlatform.Y
 	for i := 1; i < 4; i++ { // Generate fewer initial platforms
-		// Vary X position across screen width
-		xPos := 20 + (i%4)*(g.Width-40)/4
 		// Ensure minimum platform spacing going upward
-		currentY -= platformSpacing + (i % 3) // Increase spacing between platforms
-
-		platform := Platform{
-			X:        xPos,
-			Y:        currentY,
-			Width:    15 + (i%3)*10,
-			Word:     g.WordManager.GetRandomWord(),
-			Typed:    "",
-			Complete: false,
-		}
+		currentY -= g.Tuning.Spacing + (i % 3) // Increase spacing between platforms
+
+		width := 15 + g.rng.Intn(3)*10
+		platform := g.newPlatform(g.randomPlatformX(width), currentY, width)
 		g.Platforms = append(g.Platforms, platform)
 	}
 }
 
+// newPlatform creates the next platform of the run with a fresh word
+func (g *Game) newPlatform(x, y, width int) Platform {
+	platform := Platform{
+		Seq:      g.platformCount,
+		X:        x,
+		Y:        y,
+		Width:    width,
+		Word:     g.WordManager.GetRandomWord(),
+		Typed:    "",
+		Complete: false,
+	}
+	if g.platformCount > 0 {
+		platform.Type = g.randomPlatformType()
+		if create := platformKinds[platform.Type].create; create != nil {
+			create(g, &platform)
+		}
+		if platform.Type == PlatformStatic {
+			platform.PowerUp = g.randomPowerUp()
+		}
+	}
+	if g.LivesEnabled() && g.platformCount > 0 && g.platformCount%checkpointInterval == 0 {
+		// Checkpoints are plain, but draw their type all the same so the
+		// rest of the course doesn't depend on lives
+		platform = Platform{Seq: platform.Seq, X: x, Y: y, Width: width, Word: platform.Word, Checkpoint: true}
+	}
+	g.platformCount++
+	return platform
+}
+
+// randomPlatformX picks a random X position that keeps the platform on
+// screen. It is drawn on a course courseWidth wide and scaled to the screen,
+// so every terminal size gets the same course.
+func (g *Game) randomPlatformX(width int) int {
+	const margin = 2
+	course := courseWidth - width - 2*margin
+	x := g.rng.Intn(course)
+	span := g.Width - width - 2*margin
+	if span <= 0 {
+		return margin
+	}
+	return margin + x*span/course
+}
+
 func (g *Game) generateMorePlatforms() {
 	// g.Logger.Println("generateMorePlatforms")
 	if len(g.Platforms) == 0 {
@@ -334,20 +665,13 @@ func (g *Game) generateMorePlatforms() {
 	}
 
 	// Generate a new platform when the highest platform is within 10 pixels of the top
-	if highestY <= platformSpacing {
-		// Create a random X position across the screen width
-		xPos := 30 + (len(g.Platforms)%5)*(g.Width-60)/5
+	if highestY <= g.Tuning.Spacing {
 		// Place new platform above the current highest with consistent spacing
-		newY := highestY - platformSpacing // Fixed spacing between platforms
-
-		platform := Platform{
-			X:        xPos,
-			Y:        newY,
-			Width:    12 + (len(g.Platforms)%4)*6,
-			Word:     g.WordManager.GetRandomWord(),
-			Typed:    "",
-			Complete: false,
-		}
+		newY := highestY - g.Tuning.Spacing // Fixed spacing between platforms
+
+		// Width and X position come from the seeded run generator
+		width := 12 + g.rng.Intn(4)*6
+		platform := g.newPlatform(g.randomPlatformX(width), newY, width)
 		g.Platforms = append(g.Platforms, platform)
 
 		// g.Logger.Printf("Generated new platform at Y=%d (highest was at Y=%d)", newY, highestY)
@@ -388,21 +712,33 @@ func (g *Game) cleanupPlatforms() {
 // GetStats returns current game statistics
 func (g *Game) GetStats() Stats {
 	// g.Logger.Println("GetStats")
-	gameTime := time.Since(g.StartTime)
+	now := g.now()
+	if g.State == StateGameOver {
+		now = g.endedAt // The clock stops when the run ends
+	}
+	gameTime := now.Sub(g.StartTime) - g.PausedTime
+	if g.State == StatePaused {
+		gameTime -= now.Sub(g.pausedAt)
+	}
 	minutes := gameTime.Minutes()
 
 	wpm := 0.0
 	cpm := 0.0
+	accuracy := 100.0
 
 	if minutes > 0 {
 		wpm = float64(g.WordsTyped) / minutes
 		cpm = float64(g.CharsTyped) / minutes
 	}
+	if keys := g.CharsTyped + g.Mistakes; keys > 0 {
+		accuracy = float64(g.CharsTyped) / float64(keys) * 100
+	}
 
 	return Stats{
 		Score:      g.Score,
 		WPM:        wpm,
 		CPM:        cpm,
+		Accuracy:   accuracy,
 		WordsTyped: g.WordsTyped,
 		CharsTyped: g.CharsTyped,
 		GameTime:   gameTime,
@@ -413,3 +749,9 @@ func (g *Game) GetStats() Stats {
 func isAlphanumeric(r rune) bool {
 	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
 }
+
+// isTypable reports whether r can be typed in words: any printable character
+// but space. The keys of the private use area, like the arrows, can't.
+func isTypable(r rune) bool {
+	return unicode.IsGraphic(r) && !unicode.IsSpace(r)
+}
@@ -1,12 +1,13 @@
 package core
 
 import (
+	"path/filepath"
 	"testing"
 	"time"
 )
 
 func TestNewGame(t *testing.T) {
-	game, err := NewGame("test_log.txt")
+	game, err := NewGame(filepath.Join(t.TempDir(), "test_log.txt"))
 	if err != nil {
 		t.Fatalf("NewGame() error: %v", err)
 	}
@@ -25,10 +26,7 @@ func TestNewGame(t *testing.T) {
 }
 
 func TestGameStart(t *testing.T) {
-	game, err := NewGame("test_log.txt")
-	if err != nil {
-		t.Fatalf("NewGame() error: %v", err)
-	}
+	game := newTestGame(t)
 	game.Start(80, 24)
 
 	if game.Width != 80 || game.Height != 24 {
@@ -45,10 +43,7 @@ func TestGameStart(t *testing.T) {
 }
 
 func TestProcessMenuInput(t *testing.T) {
-	game, err := NewGame("test_log.txt")
-	if err != nil {
-		t.Fatalf("NewGame() error: %v", err)
-	}
+	game := newTestGame(t)
 	game.Start(80, 24)
 
 	// Test space key starts the game
@@ -68,10 +63,7 @@ func TestProcessMenuInput(t *testing.T) {
 }
 
 func TestTypingValidation(t *testing.T) {
-	game, err := NewGame("test_log.txt")
-	if err != nil {
-		t.Fatalf("NewGame() error: %v", err)
-	}
+	game := newTestGame(t)
 	game.Start(80, 24)
 	game.State = StatePlaying
 
@@ -99,10 +91,7 @@ func TestTypingValidation(t *testing.T) {
 }
 
 func TestBackspace(t *testing.T) {
-	game, err := NewGame("test_log.txt")
-	if err != nil {
-		t.Fatalf("NewGame() error: %v", err)
-	}
+	game := newTestGame(t)
 	game.Start(80, 24)
 	game.State = StatePlaying
 
@@ -117,11 +106,44 @@ func TestBackspace(t *testing.T) {
 	}
 }
 
-func TestStats(t *testing.T) {
-	game, err := NewGame("test_log.txt")
-	if err != nil {
-		t.Fatalf("NewGame() error: %v", err)
+func TestTypingMultibyteWords(t *testing.T) {
+	game := newTestGame(t)
+	game.Start(80, 24)
+	game.State = StatePlaying
+
+	platform := &game.Platforms[game.Player.Platform]
+	platform.Word = "Grüße"
+	for _, ch := range "grü" {
+		game.ProcessInput(ch)
+	}
+	if platform.Typed != "grü" {
+		t.Fatalf("Expected 'grü' typed, got %q", platform.Typed)
+	}
+	game.ProcessInput(8) // Backspace removes the whole ü, not a byte of it
+	if platform.Typed != "gr" {
+		t.Fatalf("Expected 'gr' after backspace, got %q", platform.Typed)
+	}
+	game.ProcessInput('u')
+	if platform.Typed != "gr" || game.Mistakes != 1 {
+		t.Errorf("Expected u to be wrong for ü, got %q with %d mistakes", platform.Typed, game.Mistakes)
 	}
+	for _, ch := range "üße" {
+		game.ProcessInput(ch)
+	}
+	if !platform.Complete || platform.Typed != "grüße" {
+		t.Errorf("Expected the word to complete, got %+v", platform)
+	}
+
+	platform = &game.Platforms[game.Player.Platform]
+	platform.Word, platform.Typed = "été", "ét"
+	game.ProcessInput(KeyCtrlW)
+	if platform.Typed != "" {
+		t.Errorf("Expected Ctrl+W to clear the typed characters, got %q", platform.Typed)
+	}
+}
+
+func TestStats(t *testing.T) {
+	game := newTestGame(t)
 	game.Start(80, 24)
 
 	// Simulate some typing
@@ -0,0 +1,111 @@
+package core
+
+// SetGhost races the following runs against a recorded run. The ghost's
+// settings are applied so both play the same course; for an identical course
+// the terminal should also have the size the ghost was recorded at.
+func (g *Game) SetGhost(replay *Replay, name string) error {
+	if err := replay.Validate(); err != nil {
+		return err
+	}
+	if err := g.ApplySettings(replay.Settings); err != nil {
+		return err
+	}
+	g.StartingLives = replay.Lives
+	g.Ghost = replay
+	g.GhostName = name
+	g.Logger.Printf("SetGhost: %s, %d words", name, replay.Stats.WordsTyped)
+	return nil
+}
+
+// ClearGhost stops racing against a ghost
+func (g *Game) ClearGhost() {
+	g.Ghost = nil
+	g.GhostName = ""
+}
+
+// toggleBestGhost races against the personal best of the selected mode, or
+// stops racing if a ghost is already set
+func (g *Game) toggleBestGhost() {
+	if g.Ghost != nil {
+		g.ClearGhost()
+		return
+	}
+	if g.HighScores == nil {
+		return
+	}
+	best, ok := g.HighScores.Best(g.Mode)
+	if !ok || best.Replay == "" {
+		g.MenuMessage = g.tr("No personal best replay for %s yet", g.tr(g.Mode.Name()))
+		return
+	}
+	replay, err := LoadReplay(best.Replay)
+	if err == nil {
+		err = g.SetGhost(replay, "personal best")
+	}
+	if err != nil {
+		g.MenuMessage = g.tr("Can't load the personal best: %s", err)
+	}
+}
+
+// startGhost starts the ghost alongside the run that was just reset
+func (g *Game) startGhost() {
+	if g.Ghost == nil {
+		return
+	}
+	if g.RunSettings() != g.Ghost.Settings || g.StartingLives != g.Ghost.Lives || g.Tuning != g.Ghost.Tuning ||
+		g.Errors != g.Ghost.RunErrors() || g.WordManager.IgnoreAccents != g.Ghost.Accents {
+		g.Logger.Printf("startGhost: settings differ from the ghost's, racing without it")
+		return
+	}
+	player, err := NewReplayPlayer(newGame(g.Logger), g.Ghost)
+	if err != nil {
+		g.Logger.Printf("startGhost: %v", err)
+		return
+	}
+	player.Start(g.Ghost.Width, g.Ghost.Height)
+	g.ghost = player
+}
+
+// stepGhost plays the ghost up to the game time of the live run. The ghost's
+// own pauses take no game time, so it never waits for them.
+func (g *Game) stepGhost() {
+	if g.ghost == nil {
+		return
+	}
+	for g.ghost.game.ActiveTime < g.ActiveTime && g.ghost.step() {
+	}
+}
+
+// GhostLead returns how many words the player is ahead of the ghost,
+// negative when behind. ok is false when not racing a ghost.
+func (g *Game) GhostLead() (lead int, ok bool) {
+	if g.ghost == nil {
+		return 0, false
+	}
+	return g.WordsTyped - g.ghost.game.WordsTyped, true
+}
+
+// ghostPlatform returns the Seq of the platform the ghost stands on
+func (g *Game) ghostPlatform() (seq int, ok bool) {
+	if g.ghost == nil {
+		return 0, false
+	}
+	ghost := g.ghost.game
+	if ghost.Player.Platform >= len(ghost.Platforms) {
+		return 0, false
+	}
+	return ghost.Platforms[ghost.Player.Platform].Seq, true
+}
+
+// ghostStatus describes the race for the HUD, e.g. "Ghost: +2"
+func (g *Game) ghostStatus() string {
+	lead, ok := g.GhostLead()
+	if !ok {
+		return ""
+	}
+	status := g.tr("Ghost: %+d", lead)
+	if g.ghost.Done() {
+		status += " " + g.tr("(finished)")
+	}
+	return status
+}
@@ -0,0 +1,80 @@
+package core
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestGhostRacesOnTheSameCourse(t *testing.T) {
+	replay := recordedReplay(t)
+
+	game := newPlayingGame(t)
+	game.State = StateMenu
+	if err := game.SetGhost(replay, "test"); err != nil {
+		t.Fatalf("SetGhost() error: %v", err)
+	}
+	game.ProcessInput(' ')
+	if game.ghost == nil {
+		t.Fatal("Expected the run to start with a ghost")
+	}
+	for i, platform := range game.Platforms {
+		if ghost := game.ghost.game.Platforms[i]; ghost.Word != platform.Word || ghost.Seq != platform.Seq {
+			t.Errorf("Expected the ghost's platform %d to be %q, got %q", i, platform.Word, ghost.Word)
+		}
+	}
+
+	// The ghost keeps typing while the player doesn't
+	for i := 0; i < 120 && game.State == StatePlaying; i++ {
+		game.Render()
+	}
+	if game.ghost.game.ActiveTime != game.ActiveTime {
+		t.Errorf("Expected the ghost at %v, got %v", game.ActiveTime, game.ghost.game.ActiveTime)
+	}
+	lead, ok := game.GhostLead()
+	if !ok || lead >= 0 {
+		t.Errorf("Expected the player to trail the ghost, got lead %d (racing=%v)", lead, ok)
+	}
+	if game.ghostStatus() == "" {
+		t.Error("Expected the HUD to show the race")
+	}
+
+	// Changing the course in the menu drops the ghost
+	game.State = StateMenu
+	game.ProcessInput('d')
+	if game.Ghost != nil {
+		t.Error("Expected a new difficulty to clear the ghost")
+	}
+	game.ProcessInput(' ')
+	if _, ok := game.GhostLead(); ok {
+		t.Error("Expected a run without a ghost")
+	}
+}
+
+func TestGhostPersonalBest(t *testing.T) {
+	replay := recordedReplay(t)
+	path := filepath.Join(t.TempDir(), "best.json")
+	if err := replay.Save(path); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	game := newPlayingGame(t)
+	game.State = StateMenu
+	game.HighScores = &HighScoreTable{Modes: map[string][]HighScore{}}
+	game.ProcessInput('m') // A mode without a best
+	game.ProcessInput('g')
+	if game.Ghost != nil || game.MenuMessage == "" {
+		t.Error("Expected a message when there is no personal best")
+	}
+
+	sprint, _ := ModeByID("sprint60")
+	game.Mode = sprint
+	game.HighScores.Add(sprint, HighScore{Score: replay.Stats.Score, Replay: path})
+	game.ProcessInput('g')
+	if game.Ghost == nil || game.RunSettings() != replay.Settings {
+		t.Fatalf("Expected to race the personal best on its course, got %+v", game.RunSettings())
+	}
+	game.ProcessInput('g')
+	if game.Ghost != nil {
+		t.Error("Expected G to stop racing the ghost")
+	}
+}
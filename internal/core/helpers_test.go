@@ -0,0 +1,37 @@
+package core
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// newTestGame creates a game that logs to a temporary directory
+func newTestGame(t *testing.T) *Game {
+	t.Helper()
+	game, err := NewGame(filepath.Join(t.TempDir(), "test_log.txt"))
+	if err != nil {
+		t.Fatalf("NewGame() error: %v", err)
+	}
+	return game
+}
+
+// newMenuGame creates an 80x24 game in the menu that keeps no high scores.
+// The setup functions run before the game starts.
+func newMenuGame(t *testing.T, setup ...func(*Game)) *Game {
+	t.Helper()
+	game := newTestGame(t)
+	game.HighScores = nil
+	for _, f := range setup {
+		f(game)
+	}
+	game.Start(80, 24)
+	return game
+}
+
+// newPlayingGame is newMenuGame with a run started
+func newPlayingGame(t *testing.T, setup ...func(*Game)) *Game {
+	t.Helper()
+	game := newMenuGame(t, setup...)
+	game.ProcessInput(' ')
+	return game
+}
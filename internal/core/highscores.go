@@ -0,0 +1,105 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"time"
+)
+
+const maxHighScores = 10 // entries kept per mode
+
+// HighScore is a single finished run stored in the high score table
+type HighScore struct {
+	Score    int           `json:"score"`
+	WPM      float64       `json:"wpm"`
+	Accuracy float64       `json:"accuracy"`
+	Words    int           `json:"words"`
+	Duration time.Duration `json:"duration"`
+	Date     time.Time     `json:"date"`
+	Replay   string        `json:"replay,omitempty"` // replay file of the run, if it was saved
+	Preset   string        `json:"preset,omitempty"` // ID of the difficulty preset
+}
+
+// HighScoreTable keeps the best runs of every game mode, keyed by mode ID
+type HighScoreTable struct {
+	Modes map[string][]HighScore `json:"modes"`
+	path  string
+}
+
+// LoadHighScores reads the high score table from path.
+// A missing file is not an error and yields an empty table.
+func LoadHighScores(path string) (*HighScoreTable, error) {
+	table := &HighScoreTable{
+		Modes: make(map[string][]HighScore),
+		path:  path,
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return table, nil
+	}
+	if err != nil {
+		return table, err
+	}
+	if err := json.Unmarshal(data, table); err != nil {
+		return table, err
+	}
+	if table.Modes == nil {
+		table.Modes = make(map[string][]HighScore)
+	}
+	return table, nil
+}
+
+// Save writes the table back to the file it was loaded from
+func (t *HighScoreTable) Save() error {
+	if t.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(t.path, data, 0644)
+}
+
+// Top returns the ranked entries for a mode, best first
+func (t *HighScoreTable) Top(mode GameMode) []HighScore {
+	return t.Modes[mode.ID()]
+}
+
+// Best returns the best entry for a mode, if there is one
+func (t *HighScoreTable) Best(mode GameMode) (HighScore, bool) {
+	entries := t.Modes[mode.ID()]
+	if len(entries) == 0 {
+		return HighScore{}, false
+	}
+	return entries[0], true
+}
+
+// Add inserts an entry into the mode's table using the mode's ranking.
+// It returns the 1-based rank of the new entry, or 0 if it didn't make the table.
+func (t *HighScoreTable) Add(mode GameMode, entry HighScore) int {
+	entries := append(t.Modes[mode.ID()], entry)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return mode.Better(entries[i], entries[j])
+	})
+
+	rank := 0
+	for i := range entries {
+		if entries[i] == entry {
+			rank = i + 1
+			break
+		}
+	}
+
+	if len(entries) > maxHighScores {
+		entries = entries[:maxHighScores]
+	}
+	if rank > maxHighScores {
+		rank = 0
+	}
+	t.Modes[mode.ID()] = entries
+	return rank
+}
@@ -0,0 +1,150 @@
+package core
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+const historyFile = "history.jsonl"
+
+// Session is a finished run in the session history
+type Session struct {
+	Date     time.Time   `json:"date"` // wall time the run ended
+	Settings RunSettings `json:"settings"`
+	Lives    int         `json:"lives"`
+	Daily    bool        `json:"daily"`
+	Reason   string      `json:"reason"` // why the run ended
+	Stats    Stats       `json:"stats"`
+}
+
+// SessionHistory is the log of every finished run, oldest first. It is
+// stored as one JSON object per line so a run can be appended cheaply.
+type SessionHistory struct {
+	Sessions []Session
+	path     string
+}
+
+// LoadHistory reads the session history from path.
+// A missing file is not an error and yields an empty history.
+func LoadHistory(path string) (*SessionHistory, error) {
+	history := &SessionHistory{path: path}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return history, nil
+	}
+	if err != nil {
+		return history, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var session Session
+		if err := json.Unmarshal(scanner.Bytes(), &session); err != nil {
+			return history, fmt.Errorf("%s line %d: %v", path, line, err)
+		}
+		history.Sessions = append(history.Sessions, session)
+	}
+	return history, scanner.Err()
+}
+
+// Append adds a session to the history and to the end of its file
+func (h *SessionHistory) Append(session Session) error {
+	h.Sessions = append(h.Sessions, session)
+	if h.path == "" {
+		return nil
+	}
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(h.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Filter returns the sessions of a mode, or every session for an empty mode
+func (h *SessionHistory) Filter(mode string) []Session {
+	if mode == "" {
+		return h.Sessions
+	}
+	var sessions []Session
+	for _, session := range h.Sessions {
+		if session.Settings.Mode == mode {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions
+}
+
+// DaySummary is the average of the sessions played on a day
+type DaySummary struct {
+	Date     string // local date, YYYY-MM-DD
+	Runs     int
+	WPM      float64
+	Accuracy float64
+	Score    float64
+}
+
+// ByDay averages the sessions of each of the last days ending with today,
+// oldest first. Days without sessions have no runs.
+func ByDay(sessions []Session, today time.Time, days int) []DaySummary {
+	summaries := make([]DaySummary, days)
+	index := make(map[string]int, days)
+	for i := range summaries {
+		date := today.AddDate(0, 0, i-days+1).Format(dateLayout)
+		summaries[i].Date = date
+		index[date] = i
+	}
+	for _, session := range sessions {
+		i, ok := index[session.Date.In(today.Location()).Format(dateLayout)]
+		if !ok {
+			continue
+		}
+		s := &summaries[i]
+		s.Runs++
+		s.WPM += session.Stats.WPM
+		s.Accuracy += session.Stats.Accuracy
+		s.Score += float64(session.Stats.Score)
+	}
+	for i := range summaries {
+		if runs := float64(summaries[i].Runs); runs > 0 {
+			summaries[i].WPM /= runs
+			summaries[i].Accuracy /= runs
+			summaries[i].Score /= runs
+		}
+	}
+	return summaries
+}
+
+// recordSession appends the run that just ended to the session history
+func (g *Game) recordSession() {
+	if g.History == nil {
+		return
+	}
+	session := Session{
+		Date:     g.now(),
+		Settings: g.RunSettings(),
+		Lives:    g.StartingLives,
+		Daily:    g.Daily,
+		Reason:   g.EndReason,
+		Stats:    g.GetStats(),
+	}
+	if err := g.History.Append(session); err != nil {
+		g.Logger.Printf("recordSession: failed to save history: %v", err)
+	}
+}
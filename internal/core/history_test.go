@@ -0,0 +1,115 @@
+package core
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHistoryAppendAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), historyFile)
+	history, err := LoadHistory(path)
+	if err != nil {
+		t.Fatalf("LoadHistory() error: %v", err)
+	}
+	for score := 1; score <= 3; score++ {
+		session := Session{Date: time.Now().UTC(), Settings: RunSettings{Mode: "zen", Seed: 7}, Stats: Stats{Score: score}}
+		if err := history.Append(session); err != nil {
+			t.Fatalf("Append() error: %v", err)
+		}
+	}
+
+	loaded, err := LoadHistory(path)
+	if err != nil {
+		t.Fatalf("LoadHistory() error: %v", err)
+	}
+	if len(loaded.Sessions) != 3 || loaded.Sessions[2].Stats.Score != 3 || loaded.Sessions[0].Settings.Seed != 7 {
+		t.Errorf("Expected the three sessions back in order, got %+v", loaded.Sessions)
+	}
+	if got := len(loaded.Filter("survival")); got != 0 {
+		t.Errorf("Expected no survival sessions, got %d", got)
+	}
+}
+
+func TestEndRunRecordsSession(t *testing.T) {
+	game := newPlayingGame(t)
+	game.History = &SessionHistory{}
+	typeCurrentWord(game, false)
+	game.ProcessInput(27)
+	game.ProcessInput('\r')
+
+	if len(game.History.Sessions) != 1 {
+		t.Fatalf("Expected one session, got %d", len(game.History.Sessions))
+	}
+	session := game.History.Sessions[0]
+	if session.Settings != game.RunSettings() || session.Reason != "Run ended" {
+		t.Errorf("Expected the run's settings and end reason, got %+v", session)
+	}
+	if session.Stats != game.GetStats() {
+		t.Errorf("Expected stats %+v, got %+v", game.GetStats(), session.Stats)
+	}
+}
+
+func TestByDay(t *testing.T) {
+	today := time.Date(2024, 3, 9, 18, 0, 0, 0, time.UTC)
+	sessions := []Session{
+		{Date: today.AddDate(0, 0, -10), Stats: Stats{WPM: 99}}, // Too old
+		{Date: today.AddDate(0, 0, -1), Stats: Stats{WPM: 30, Score: 100}},
+		{Date: today, Stats: Stats{WPM: 40, Score: 200}},
+		{Date: today, Stats: Stats{WPM: 60, Score: 400}},
+	}
+	days := ByDay(sessions, today, 7)
+	if len(days) != 7 || days[6].Date != "2024-03-09" {
+		t.Fatalf("Expected a week ending today, got %+v", days)
+	}
+	if days[6].Runs != 2 || days[6].WPM != 50 || days[6].Score != 300 {
+		t.Errorf("Expected today's averages, got %+v", days[6])
+	}
+	if days[5].Runs != 1 || days[0].Runs != 0 {
+		t.Errorf("Expected runs only yesterday and today, got %+v", days)
+	}
+}
+
+func TestSparklineAndPersonalBests(t *testing.T) {
+	values := []float64{10, 20, 15, 30, 30}
+	line := sparkline(values)
+	if len(line) != len(values) || line[0] != sparkLevels[0] || line[3] != sparkLevels[len(sparkLevels)-1] {
+		t.Errorf("Expected the lowest and highest levels at the extremes, got %q", line)
+	}
+	if got := markers(personalBests(values)); got != "^^ ^ " {
+		t.Errorf("Expected carets on improvements only, got %q", got)
+	}
+	if got := bar(5, 10, 10); got != "#####     " {
+		t.Errorf("Expected a half filled bar, got %q", got)
+	}
+}
+
+func TestProgressScreen(t *testing.T) {
+	game := newPlayingGame(t)
+	game.State = StateMenu
+	game.History = &SessionHistory{}
+	for _, wpm := range []float64{20, 35, 30} {
+		game.History.Append(Session{Date: time.Now(), Settings: game.RunSettings(), Stats: Stats{WPM: wpm, Accuracy: 95}})
+	}
+
+	game.ProcessInput('s')
+	if game.State != StateProgress {
+		t.Fatalf("Expected S to open the progress screen, got state %v", game.State)
+	}
+	screen := game.Render()
+	for _, want := range []string{"PROGRESS", "Last 3 of 3 sessions", "best 35.0", "PB"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected the progress screen to show %q", want)
+		}
+	}
+
+	game.ProcessInput('m') // First single mode
+	if game.progressMode != gameModes[0].ID() {
+		t.Errorf("Expected M to filter by %s, got %q", gameModes[0].ID(), game.progressMode)
+	}
+	game.ProcessInput(27)
+	if game.State != StateMenu {
+		t.Errorf("Expected ESC to return to the menu, got state %v", game.State)
+	}
+}
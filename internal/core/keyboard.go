@@ -0,0 +1,94 @@
+package core
+
+import "slices"
+
+// HandStats sums up the key analytics by how the keys are typed on a layout
+type HandStats struct {
+	Hands       [2]KeyStat // keys typed by the left and right hand
+	Fingers     [8]KeyStat // keys typed by each finger, see Finger
+	Alternating KeyStat    // pairs typed by one hand, then the other
+	SameHand    KeyStat    // pairs typed by two fingers of the same hand
+	SameFinger  KeyStat    // pairs typed twice by the same finger
+}
+
+// Pairs returns how many key pairs the stats are made of
+func (h HandStats) Pairs() int {
+	return h.Alternating.attempts() + h.SameHand.attempts() + h.SameFinger.attempts()
+}
+
+// WeakestFinger returns the finger with the lowest accuracy, false if no
+// key was typed yet
+func (h HandStats) WeakestFinger() (Finger, bool) {
+	weakest := Finger(0)
+	for i, stat := range h.Fingers {
+		if stat.attempts() > 0 && (weakest == 0 || stat.Accuracy() < h.Fingers[weakest-1].Accuracy()) {
+			weakest = Finger(i + 1)
+		}
+	}
+	return weakest, weakest != 0
+}
+
+// HandStats sums up the analytics of the keys and key pairs on layout.
+// Characters that aren't on the layout are left out.
+func (s *KeyStats) HandStats(layout *Layout) HandStats {
+	var h HandStats
+	for key, stat := range s.Keys {
+		pos, ok := layout.Key([]rune(key)[0])
+		if !ok {
+			continue
+		}
+		h.Hands[pos.Finger.Hand()] = h.Hands[pos.Finger.Hand()].merge(stat)
+		h.Fingers[pos.Finger-1] = h.Fingers[pos.Finger-1].merge(stat)
+	}
+	for pair, stat := range s.Pairs {
+		keys := []rune(pair)
+		first, ok1 := layout.Key(keys[0])
+		second, ok2 := layout.Key(keys[len(keys)-1])
+		switch {
+		case !ok1 || !ok2:
+		case first.Finger.Hand() != second.Finger.Hand():
+			h.Alternating = h.Alternating.merge(stat)
+		case first.Finger != second.Finger:
+			h.SameHand = h.SameHand.merge(stat)
+		default:
+			h.SameFinger = h.SameFinger.merge(stat)
+		}
+	}
+	return h
+}
+
+// attempts returns how often the key was typed, right or wrong
+func (k KeyStat) attempts() int {
+	return k.Hits + k.Misses
+}
+
+// merge returns the sum of two stats
+func (k KeyStat) merge(other KeyStat) KeyStat {
+	return KeyStat{
+		Hits:    k.Hits + other.Hits,
+		Misses:  k.Misses + other.Misses,
+		Latency: k.Latency + other.Latency,
+		Timed:   k.Timed + other.Timed,
+	}
+}
+
+// setLayout switches the active profile to another keyboard layout
+func (g *Game) setLayout(layout *Layout) {
+	g.Layout = layout
+	g.saveProfileSettings()
+}
+
+// processKeyboardInput handles the keyboard screen: L changes the layout and
+// a number starts a drill
+func (g *Game) processKeyboardInput(key rune) {
+	g.Logger.Debugf("processKeyboardInput: key=%v", key)
+	g.MenuMessage = ""
+	switch {
+	case key >= '1' && int(key-'1') < len(drills):
+		g.startDrill(drills[key-'1'])
+	case key == 'l' || key == 'L':
+		g.setLayout(layouts[cycle(len(layouts), slices.Index(layouts, g.Layout), 1)])
+	case key == 27 || key == 'q' || key == 'Q':
+		g.State = StateMenu
+	}
+}
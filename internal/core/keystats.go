@@ -0,0 +1,164 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"time"
+	"unicode/utf8"
+)
+
+const keyStatsFile = "keys.json"
+
+// KeyStat is how a single key has been typed over all runs
+type KeyStat struct {
+	Hits    int           `json:"hits"`    // times it was typed correctly
+	Misses  int           `json:"misses"`  // times another key was pressed instead
+	Latency time.Duration `json:"latency"` // total time taken to reach it, over Timed hits
+	Timed   int           `json:"timed"`   // hits that weren't the first key of a word
+}
+
+// Accuracy returns the percentage of attempts at the key that were correct
+func (k KeyStat) Accuracy() float64 {
+	if k.Hits+k.Misses == 0 {
+		return 100
+	}
+	return float64(k.Hits) / float64(k.Hits+k.Misses) * 100
+}
+
+// AverageLatency returns the average time taken to reach the key
+func (k KeyStat) AverageLatency() time.Duration {
+	if k.Timed == 0 {
+		return 0
+	}
+	return k.Latency / time.Duration(k.Timed)
+}
+
+// KeyStats keeps per-key analytics, keyed by the expected character, and
+// the same for pairs of keys typed one after the other
+type KeyStats struct {
+	Keys  map[string]KeyStat `json:"keys"`
+	Pairs map[string]KeyStat `json:"pairs,omitempty"` // keyed by both characters, e.g. "th"
+	path  string
+}
+
+// LoadKeyStats reads the key analytics from path.
+// A missing file is not an error and yields empty analytics.
+func LoadKeyStats(path string) (*KeyStats, error) {
+	stats := &KeyStats{
+		Keys:  make(map[string]KeyStat),
+		Pairs: make(map[string]KeyStat),
+		path:  path,
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return stats, nil
+	}
+	if err != nil {
+		return stats, err
+	}
+	if err := json.Unmarshal(data, stats); err != nil {
+		return stats, err
+	}
+	if stats.Keys == nil {
+		stats.Keys = make(map[string]KeyStat)
+	}
+	if stats.Pairs == nil {
+		stats.Pairs = make(map[string]KeyStat)
+	}
+	// Drop what a hand-edited or truncated file may hold besides single
+	// characters and pairs of them
+	for key := range stats.Keys {
+		if utf8.RuneCountInString(key) != 1 {
+			delete(stats.Keys, key)
+		}
+	}
+	for pair := range stats.Pairs {
+		if utf8.RuneCountInString(pair) != 2 {
+			delete(stats.Pairs, pair)
+		}
+	}
+	return stats, nil
+}
+
+// Save writes the analytics back to the file they were loaded from
+func (s *KeyStats) Save() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0644)
+}
+
+// Record adds an attempt at the expected key. latency is the time since the
+// previous key of the same word, 0 for the first key of a word.
+func (s *KeyStats) Record(expected rune, correct bool, latency time.Duration) {
+	s.Keys[string(expected)] = s.Keys[string(expected)].add(correct, latency)
+}
+
+// RecordPair adds an attempt at the expected key right after previous
+func (s *KeyStats) RecordPair(previous, expected rune, correct bool, latency time.Duration) {
+	if s.Pairs == nil {
+		s.Pairs = make(map[string]KeyStat)
+	}
+	pair := string([]rune{previous, expected})
+	s.Pairs[pair] = s.Pairs[pair].add(correct, latency)
+}
+
+// add returns the stat with an attempt added
+func (k KeyStat) add(correct bool, latency time.Duration) KeyStat {
+	if !correct {
+		k.Misses++
+	} else {
+		k.Hits++
+		if latency > 0 {
+			k.Latency += latency
+			k.Timed++
+		}
+	}
+	return k
+}
+
+// Weakest returns up to n keys with the lowest accuracy, worst first
+func (s *KeyStats) Weakest(n int) []string {
+	keys := make([]string, 0, len(s.Keys))
+	for key := range s.Keys {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := s.Keys[keys[i]].Accuracy(), s.Keys[keys[j]].Accuracy()
+		if a != b {
+			return a < b
+		}
+		return keys[i] < keys[j]
+	})
+	if len(keys) > n {
+		keys = keys[:n]
+	}
+	return keys
+}
+
+// recordKey adds a typed key to the analytics of the active profile
+func (g *Game) recordKey(platform *Platform, correct bool) {
+	word, typed := []rune(platform.Word), utf8.RuneCountInString(platform.Typed)
+	if g.KeyStats == nil || typed >= len(word) {
+		return
+	}
+	expected := word[typed]
+	var latency time.Duration
+	if platform.Typed != "" {
+		latency = g.ActiveTime - g.lastKeyAt
+	}
+	g.KeyStats.Record(expected, correct, latency)
+	if platform.Typed != "" {
+		g.KeyStats.RecordPair(word[typed-1], expected, correct, latency)
+	}
+	if correct {
+		g.lastKeyAt = g.ActiveTime
+	}
+}
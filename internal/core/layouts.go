@@ -0,0 +1,292 @@
+package core
+
+import (
+	"ascii-type/assets"
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+const layoutDirName = "layouts"
+
+// ErrInvalidLayout is returned for layout files that can't be read
+var ErrInvalidLayout = errors.New("invalid layout")
+
+// Row is a row of keys, from the number row down
+type Row int
+
+const (
+	RowNumber Row = iota
+	RowTop
+	RowHome
+	RowBottom
+)
+
+// rowNames are the row names used in layout files, in Row order
+var rowNames = []string{"number", "top", "home", "bottom"}
+
+// Finger types a key. 1 to 4 are the left pinky to index finger, 5 to 8 the
+// right index to pinky finger.
+type Finger int
+
+const (
+	FingerLeftPinky Finger = iota + 1
+	FingerLeftRing
+	FingerLeftMiddle
+	FingerLeftIndex
+	FingerRightIndex
+	FingerRightMiddle
+	FingerRightRing
+	FingerRightPinky
+)
+
+// fingerNames are the finger names shown on screen, in Finger order
+var fingerNames = []string{"left pinky", "left ring", "left middle", "left index",
+	"right index", "right middle", "right ring", "right pinky"}
+
+// defaultFingers are the touch typing fingers of the keys of each row, from
+// the left. Keys past the end are typed by the last finger.
+var defaultFingers = [][]Finger{
+	{1, 1, 2, 3, 4, 4, 5, 5, 6, 7, 8},
+	{1, 2, 3, 4, 4, 5, 5, 6, 7, 8},
+	{1, 2, 3, 4, 4, 5, 5, 6, 7, 8},
+	{1, 2, 3, 4, 4, 5, 5, 6, 7, 8},
+}
+
+// Name returns how the finger is shown on screen, e.g. "left ring"
+func (f Finger) Name() string {
+	if f < FingerLeftPinky || f > FingerRightPinky {
+		return "unknown"
+	}
+	return fingerNames[f-1]
+}
+
+// Hand is a hand, left or right
+type Hand int
+
+const (
+	HandLeft Hand = iota
+	HandRight
+)
+
+// Hand returns the hand the finger belongs to
+func (f Finger) Hand() Hand {
+	if f <= FingerLeftIndex {
+		return HandLeft
+	}
+	return HandRight
+}
+
+// KeyPosition is where a character is typed on a layout
+type KeyPosition struct {
+	Row    Row
+	Finger Finger
+	Shift  bool // typed with Shift held
+}
+
+// Layout maps the characters of a keyboard layout to their keys
+type Layout struct {
+	ID   string
+	Name string
+	Rows [4][]rune // unshifted characters of every row, from the left
+	keys map[rune]KeyPosition
+}
+
+// Key returns where r is typed
+func (l *Layout) Key(r rune) (KeyPosition, bool) {
+	pos, ok := l.keys[r]
+	return pos, ok
+}
+
+// ParseLayout reads a layout file, see assets/layouts/qwerty.txt for the
+// format. id is the layout's ID, usually its file name.
+func ParseLayout(id string, r io.Reader) (*Layout, error) {
+	layout := &Layout{ID: id, keys: make(map[rune]KeyPosition)}
+	var shifts [4][]rune
+	var fingers [4][]Finger
+	last := Row(-1) // row that shift and fingers lines apply to
+	lineNo := 0
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		word, value, _ := strings.Cut(line, " ")
+		value = strings.TrimSpace(value)
+		keys := []rune(value)
+		switch row := Row(slices.Index(rowNames, word)); {
+		case word == "name":
+			layout.Name = value
+		case row >= 0:
+			if len(layout.Rows[row]) > 0 {
+				return nil, fmt.Errorf("%w: %s line %d: %s row given twice", ErrInvalidLayout, id, lineNo, word)
+			}
+			layout.Rows[row] = keys
+			fingers[row] = make([]Finger, len(keys))
+			for col := range keys {
+				fingers[row][col] = defaultFinger(row, col)
+			}
+			last = row
+		case last < 0 && (word == "shift" || word == "fingers"):
+			return nil, fmt.Errorf("%w: %s line %d: %s before any row", ErrInvalidLayout, id, lineNo, word)
+		case word == "shift":
+			if len(keys) > len(layout.Rows[last]) {
+				return nil, fmt.Errorf("%w: %s line %d: more shifted keys than keys", ErrInvalidLayout, id, lineNo)
+			}
+			shifts[last] = keys
+		case word == "fingers":
+			if len(keys) != len(layout.Rows[last]) {
+				return nil, fmt.Errorf("%w: %s line %d: %d fingers for %d keys",
+					ErrInvalidLayout, id, lineNo, len(keys), len(layout.Rows[last]))
+			}
+			for col, digit := range keys {
+				finger := Finger(digit - '0')
+				if finger < FingerLeftPinky || finger > FingerRightPinky {
+					return nil, fmt.Errorf("%w: %s line %d: fingers are 1 to 8, got %q", ErrInvalidLayout, id, lineNo, digit)
+				}
+				fingers[last][col] = finger
+			}
+		default:
+			return nil, fmt.Errorf("%w: %s line %d: unknown line %q, expected name, %s, shift or fingers",
+				ErrInvalidLayout, id, lineNo, word, strings.Join(rowNames, ", "))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if layout.Name == "" {
+		return nil, fmt.Errorf("%w: %s has no name", ErrInvalidLayout, id)
+	}
+	if len(layout.Rows[RowHome]) == 0 {
+		return nil, fmt.Errorf("%w: %s has no home row", ErrInvalidLayout, id)
+	}
+
+	// Unshifted characters first, so a character on two keys is typed
+	// without Shift
+	for row, keys := range layout.Rows {
+		for col, key := range keys {
+			layout.add(key, KeyPosition{Row: Row(row), Finger: fingers[row][col]})
+		}
+	}
+	for row, keys := range layout.Rows {
+		for col, key := range keys {
+			pos := KeyPosition{Row: Row(row), Finger: fingers[row][col], Shift: true}
+			if col < len(shifts[row]) {
+				layout.add(shifts[row][col], pos)
+			}
+			layout.add(unicode.ToUpper(key), pos)
+		}
+	}
+	return layout, nil
+}
+
+// add maps a character to its key. A character already on the layout keeps
+// its first key.
+func (l *Layout) add(r rune, pos KeyPosition) {
+	if _, ok := l.keys[r]; !ok {
+		l.keys[r] = pos
+	}
+}
+
+// defaultFinger returns the touch typing finger of column col of row
+func defaultFinger(row Row, col int) Finger {
+	fingers := defaultFingers[row]
+	return fingers[min(col, len(fingers)-1)]
+}
+
+// layouts holds every layout, the bundled ones first; the first one is the default
+var layouts = loadBundledLayouts()
+
+// bundledLayouts are the layout files in the assets package, in menu order
+var bundledLayouts = []string{"qwerty", "dvorak", "colemak", "azerty"}
+
+func loadBundledLayouts() []*Layout {
+	var list []*Layout
+	for _, id := range bundledLayouts {
+		file, err := assets.Files.Open(path.Join(layoutDirName, id+".txt"))
+		if err != nil {
+			panic(fmt.Sprintf("bundled layout %s: %v", id, err))
+		}
+		layout, err := ParseLayout(id, file)
+		file.Close()
+		if err != nil {
+			panic(fmt.Sprintf("bundled layout %s: %v", id, err))
+		}
+		list = append(list, layout)
+	}
+	return list
+}
+
+// Layouts returns the available layouts in menu order
+func Layouts() []*Layout {
+	return layouts
+}
+
+// LayoutByID looks up a layout by its ID
+func LayoutByID(id string) (*Layout, bool) {
+	for _, layout := range layouts {
+		if layout.ID == id {
+			return layout, true
+		}
+	}
+	return nil, false
+}
+
+// LayoutDir returns the directory in the user's config directory that
+// layouts of their own are read from
+func LayoutDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, configDirName, layoutDirName), nil
+}
+
+// LoadLayouts adds the layouts in the .txt files of dir, named after their
+// files. A layout with the ID of a bundled one replaces it. A missing
+// directory is not an error.
+func LoadLayouts(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.txt"))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		id := strings.ToLower(strings.TrimSuffix(filepath.Base(file), ".txt"))
+		if strings.IndexFunc(id, func(r rune) bool { return !isAlphanumeric(r) && r != '_' }) >= 0 {
+			return fmt.Errorf("%w: %s: file names may only use letters, digits and _", ErrInvalidLayout, file)
+		}
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		layout, err := ParseLayout(id, f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+		if i := slices.Index(layoutIDs(), id); i >= 0 {
+			layouts[i] = layout
+		} else {
+			layouts = append(layouts, layout)
+		}
+	}
+	return nil
+}
+
+// layoutIDs returns the IDs of every layout in menu order
+func layoutIDs() []string {
+	ids := make([]string, len(layouts))
+	for i, layout := range layouts {
+		ids[i] = layout.ID
+	}
+	return ids
+}
@@ -0,0 +1,216 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBundledLayouts(t *testing.T) {
+	tests := []struct {
+		layout string
+		key    rune
+		want   KeyPosition
+	}{
+		{"qwerty", 'f', KeyPosition{RowHome, FingerLeftIndex, false}},
+		{"qwerty", 'J', KeyPosition{RowHome, FingerRightIndex, true}},
+		{"qwerty", '!', KeyPosition{RowNumber, FingerLeftPinky, true}},
+		{"qwerty", 'p', KeyPosition{RowTop, FingerRightPinky, false}},
+		{"dvorak", 'u', KeyPosition{RowHome, FingerLeftIndex, false}},
+		{"dvorak", 'z', KeyPosition{RowBottom, FingerRightPinky, false}},
+		{"colemak", 't', KeyPosition{RowHome, FingerLeftIndex, false}},
+		{"azerty", 'w', KeyPosition{RowBottom, FingerLeftPinky, false}},
+		{"azerty", 'n', KeyPosition{RowBottom, FingerRightIndex, false}},
+		{"azerty", '1', KeyPosition{RowNumber, FingerLeftPinky, true}},
+		{"azerty", 'é', KeyPosition{RowNumber, FingerLeftRing, false}},
+	}
+	for _, tt := range tests {
+		layout, ok := LayoutByID(tt.layout)
+		if !ok {
+			t.Fatalf("Expected a bundled %s layout", tt.layout)
+		}
+		if got, ok := layout.Key(tt.key); !ok || got != tt.want {
+			t.Errorf("%s %q: got %+v, want %+v", tt.layout, tt.key, got, tt.want)
+		}
+	}
+	for _, layout := range Layouts() {
+		for r := 'a'; r <= 'z'; r++ {
+			if _, ok := layout.Key(r); !ok {
+				t.Errorf("Expected %q on %s", r, layout.Name)
+			}
+		}
+	}
+}
+
+func TestParseLayoutErrors(t *testing.T) {
+	tests := []struct {
+		file string
+		want string
+	}{
+		{"name X\nhome asdf\nthumbs x", `unknown line "thumbs"`},
+		{"name X\nshift ASDF\nhome asdf", "shift before any row"},
+		{"name X\nhome asdf\nfingers 123", "3 fingers for 4 keys"},
+		{"name X\nhome asdf\nfingers 1239", "fingers are 1 to 8"},
+		{"name X\nhome asdf\nshift ASDFG", "more shifted keys"},
+		{"name X\ntop qwer", "no home row"},
+		{"home asdf", "no name"},
+	}
+	for _, tt := range tests {
+		if _, err := ParseLayout("x", strings.NewReader(tt.file)); !errors.Is(err, ErrInvalidLayout) || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%q: expected an error containing %q, got %v", tt.file, tt.want, err)
+		}
+	}
+}
+
+func TestLoadLayouts(t *testing.T) {
+	saved := slices.Clone(layouts)
+	t.Cleanup(func() { layouts = saved })
+
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "Workman.txt"), []byte("name Workman\ntop qdrwbjfup\nhome ashtgyneoi\nbottom zxmcvkl"), 0644)
+	os.WriteFile(filepath.Join(dir, "qwerty.txt"), []byte("name My QWERTY\nhome asdfghjkl"), 0644)
+	if err := LoadLayouts(dir); err != nil {
+		t.Fatalf("LoadLayouts() error: %v", err)
+	}
+	workman, ok := LayoutByID("workman")
+	if !ok || workman.Name != "Workman" || len(Layouts()) != len(saved)+1 {
+		t.Fatalf("Expected the Workman layout to be added, got %v", layoutIDs())
+	}
+	if pos, _ := workman.Key('h'); pos.Row != RowHome || pos.Finger != FingerLeftMiddle {
+		t.Errorf("Expected h under the left middle finger, got %+v", pos)
+	}
+	if Layouts()[0].Name != "My QWERTY" {
+		t.Errorf("Expected the QWERTY file to replace the bundled one, got %s", Layouts()[0].Name)
+	}
+	if err := LoadLayouts(filepath.Join(dir, "missing")); err != nil {
+		t.Errorf("Expected a missing directory to be fine, got %v", err)
+	}
+
+	os.WriteFile(filepath.Join(dir, "my-layout.txt"), []byte("name Mine\nhome asdf"), 0644)
+	if err := LoadLayouts(dir); !errors.Is(err, ErrInvalidLayout) {
+		t.Errorf("Expected a file name that can't be an ID to be refused, got %v", err)
+	}
+}
+
+func TestHandStats(t *testing.T) {
+	stats, _ := LoadKeyStats(filepath.Join(t.TempDir(), keyStatsFile))
+	stats.Record('f', true, 0)
+	stats.Record('j', true, 100*time.Millisecond)
+	stats.Record('d', false, 0)
+	stats.RecordPair('f', 'j', true, 100*time.Millisecond) // Alternating
+	stats.RecordPair('f', 'd', false, 0)                   // Same hand
+	stats.RecordPair('f', 'r', true, 300*time.Millisecond) // Same finger
+	stats.RecordPair('f', 'é', true, 300*time.Millisecond) // Not on QWERTY
+
+	qwerty, _ := LayoutByID("qwerty")
+	hands := stats.HandStats(qwerty)
+	if hands.Pairs() != 3 || hands.Alternating.Hits != 1 || hands.SameHand.Misses != 1 || hands.SameFinger.Hits != 1 {
+		t.Errorf("Unexpected pair stats %+v", hands)
+	}
+	if hands.Hands[HandLeft].Hits != 1 || hands.Hands[HandLeft].Misses != 1 || hands.Hands[HandRight].Hits != 1 {
+		t.Errorf("Unexpected hand stats %+v", hands.Hands)
+	}
+	if finger, ok := hands.WeakestFinger(); !ok || finger != FingerLeftMiddle {
+		t.Errorf("Expected the left middle finger to be the weakest, got %s", finger.Name())
+	}
+
+	dvorak, _ := LayoutByID("dvorak")
+	if hands := stats.HandStats(dvorak); hands.Alternating.Hits != 1 || hands.SameFinger.Misses != 1 || hands.SameHand.Hits != 1 {
+		t.Errorf("Expected the pairs to be classified by the layout, got %+v", hands)
+	}
+}
+
+func TestKeyStatsDropBadKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), keyStatsFile)
+	data := `{"keys": {"": {"hits": 1}, "ab": {"hits": 2}, "f": {"hits": 3}}, "pairs": {"": {"hits": 1}, "x": {"hits": 1}, "fj": {"hits": 4}}}`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	stats, err := LoadKeyStats(path)
+	if err != nil {
+		t.Fatalf("LoadKeyStats() error: %v", err)
+	}
+	if len(stats.Keys) != 1 || len(stats.Pairs) != 1 {
+		t.Errorf("Expected only the key f and the pair fj, got %v and %v", stats.Keys, stats.Pairs)
+	}
+	qwerty, _ := LayoutByID("qwerty")
+	if hands := stats.HandStats(qwerty); hands.Hands[HandLeft].Hits != 3 || hands.Alternating.Hits != 4 {
+		t.Errorf("Unexpected hand stats %+v", hands)
+	}
+}
+
+func TestDrillPacks(t *testing.T) {
+	for _, layout := range Layouts() {
+		for _, drill := range Drills() {
+			pack, ok := WordPackByID(DrillPackID(drill, layout))
+			if !ok || len(pack.Words) != drillWords {
+				t.Fatalf("Expected %d words for %s, got %v", drillWords, DrillPackID(drill, layout), pack)
+			}
+			again, _ := drillPack(drill, layout)
+			if !slices.Equal(pack.Words, again.Words) {
+				t.Errorf("Expected %s to yield the same words every time", pack.ID)
+			}
+			for _, word := range pack.Words {
+				for i, r := range word {
+					pos, ok := layout.Key(r)
+					if !ok || !drill.Keys(pos) || pos.Shift {
+						t.Fatalf("%s: %q isn't made of the drill's keys", pack.ID, word)
+					}
+					if prev, _ := layout.Key(rune(word[max(i-1, 0)])); drill.Alternate && i > 0 && prev.Finger.Hand() == pos.Finger.Hand() {
+						t.Fatalf("%s: %q doesn't alternate hands", pack.ID, word)
+					}
+				}
+			}
+		}
+	}
+
+	code := mustEncode(t, RunSettings{Mode: "survival", Pack: "drill:home:colemak", Difficulty: 1, Seed: 42}, 0)
+	if settings, _, err := DecodeChallenge(code); err != nil || settings.Pack != "drill:home:colemak" {
+		t.Errorf("Expected drills to work in challenge codes, got %+v, %v", settings, err)
+	}
+	for _, id := range []string{"drill:home", "drill:thumbs:qwerty", "drill:home:klingon"} {
+		if _, ok := WordPackByID(id); ok {
+			t.Errorf("Expected no pack for %s", id)
+		}
+	}
+}
+
+func TestKeyboardScreen(t *testing.T) {
+	game := profileGame(t)
+	game.ProcessInput('k')
+	if game.State != StateKeyboard || !strings.Contains(game.Render(), "Layout: QWERTY") {
+		t.Fatalf("Expected K to open the keyboard screen, got state %v", game.State)
+	}
+
+	game.ProcessInput('l')
+	if game.Layout.ID != "dvorak" || game.Profile.Settings.Layout != "dvorak" {
+		t.Fatalf("Expected L to switch the profile to Dvorak, got %s", game.Layout.ID)
+	}
+	reopened, _ := game.Profiles.Open(game.Profile.Name)
+	if reopened.Settings.Layout != "dvorak" {
+		t.Errorf("Expected the layout to be saved with the profile, got %q", reopened.Settings.Layout)
+	}
+
+	game.ProcessInput('1')
+	if game.State != StatePlaying || game.WordManager.Pack != "drill:home:dvorak" {
+		t.Fatalf("Expected 1 to start the home row drill, got state %v and pack %s", game.State, game.WordManager.Pack)
+	}
+	word := game.Platforms[game.Player.Platform].Word
+	for _, r := range word {
+		if pos, _ := game.Layout.Key(r); pos.Row != RowHome {
+			t.Errorf("Expected home row keys only, got %q", word)
+		}
+	}
+	typeCurrentWord(game, false)
+	if len(game.KeyStats.Pairs) == 0 {
+		t.Error("Expected the key pairs of the word to be recorded")
+	}
+	game.State = StateKeyboard
+	if frame := game.Render(); !strings.Contains(frame, "Pairs: ") || !strings.Contains(frame, "Weakest: ") {
+		t.Error("Expected the hand and pair stats on the keyboard screen")
+	}
+}
@@ -0,0 +1,359 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const lessonsFile = "lessons.json"
+
+// lessonPrefix starts the pack IDs of lessons, "lesson:<lesson>:<layout>"
+const lessonPrefix = "lesson:"
+
+// lessonWords is how many words a lesson run takes
+const lessonWords = 20
+
+// Lesson is a step of the curriculum: words made of a restricted set of
+// characters, passed by typing them fast and accurately enough
+type Lesson struct {
+	ID          string
+	Name        string
+	Keys        func(r rune, pos KeyPosition) bool // characters the words are made of
+	New         func(r rune, pos KeyPosition) bool // characters the lesson introduces, in every word
+	MinWPM      float64
+	MinAccuracy float64
+}
+
+// lessons holds the curriculum in order; passing a lesson unlocks the next
+var lessons = []*Lesson{
+	{ID: "home", Name: "Home row", MinWPM: 15, MinAccuracy: 90,
+		Keys: func(r rune, pos KeyPosition) bool { return isLessonLetter(r) && pos.Row == RowHome },
+		New:  func(r rune, pos KeyPosition) bool { return pos.Row == RowHome }},
+	{ID: "top", Name: "Top row", MinWPM: 18, MinAccuracy: 92,
+		Keys: func(r rune, pos KeyPosition) bool {
+			return isLessonLetter(r) && (pos.Row == RowHome || pos.Row == RowTop)
+		},
+		New: func(r rune, pos KeyPosition) bool { return pos.Row == RowTop }},
+	{ID: "bottom", Name: "Bottom row", MinWPM: 20, MinAccuracy: 92,
+		Keys: func(r rune, pos KeyPosition) bool { return isLessonLetter(r) },
+		New:  func(r rune, pos KeyPosition) bool { return pos.Row == RowBottom }},
+	{ID: "numbers", Name: "Numbers", MinWPM: 15, MinAccuracy: 90,
+		Keys: func(r rune, pos KeyPosition) bool { return isLessonLetter(r) || isDigit(r) },
+		New:  func(r rune, pos KeyPosition) bool { return isDigit(r) }},
+	{ID: "symbols", Name: "Symbols", MinWPM: 12, MinAccuracy: 90,
+		Keys: func(r rune, pos KeyPosition) bool { return isLessonLetter(r) || isDigit(r) || isSymbol(r) },
+		New:  func(r rune, pos KeyPosition) bool { return isSymbol(r) }},
+}
+
+func isLessonLetter(r rune) bool { return r >= 'a' && r <= 'z' }
+func isDigit(r rune) bool        { return r >= '0' && r <= '9' }
+func isSymbol(r rune) bool {
+	return r <= '~' && isTypable(r) && (unicode.IsPunct(r) || unicode.IsSymbol(r))
+}
+
+// Lessons returns the curriculum in order
+func Lessons() []*Lesson {
+	return lessons
+}
+
+// LessonByID looks up a lesson by its ID
+func LessonByID(id string) (*Lesson, bool) {
+	for _, lesson := range lessons {
+		if lesson.ID == id {
+			return lesson, true
+		}
+	}
+	return nil, false
+}
+
+// LessonPackID returns the ID of the word pack of lesson on layout
+func LessonPackID(lesson *Lesson, layout *Layout) string {
+	return lessonPrefix + lesson.ID + ":" + layout.ID
+}
+
+// lessonPackByID builds the word pack of a lesson pack ID
+func lessonPackByID(id string) (*WordPack, bool) {
+	lesson, layout, ok := parseLessonPackID(id)
+	if !ok {
+		return nil, false
+	}
+	return lessonPack(lesson, layout)
+}
+
+// parseLessonPackID returns the lesson and layout of a lesson pack ID
+func parseLessonPackID(id string) (*Lesson, *Layout, bool) {
+	rest, ok := strings.CutPrefix(id, lessonPrefix)
+	lessonID, layoutID, found := strings.Cut(rest, ":")
+	if !ok || !found {
+		return nil, nil, false
+	}
+	lesson, ok := LessonByID(lessonID)
+	layout, found := LayoutByID(layoutID)
+	return lesson, layout, ok && found
+}
+
+// Characters returns the characters of the lesson on layout, sorted, and the
+// ones among them that the lesson introduces
+func (l *Lesson) Characters(layout *Layout) (keys, introduced []rune) {
+	for r, pos := range layout.keys {
+		if l.Keys(r, pos) {
+			keys = append(keys, r)
+			if l.New(r, pos) {
+				introduced = append(introduced, r)
+			}
+		}
+	}
+	slices.Sort(keys)
+	slices.Sort(introduced)
+	return keys, introduced
+}
+
+// lessonPack builds the words of lesson on layout: the words of the bundled
+// packs made of its characters, topped up with pseudo-words of them. Every
+// word has at least one of the characters the lesson introduces. It returns
+// false if the layout has none of them.
+func lessonPack(lesson *Lesson, layout *Layout) (*WordPack, bool) {
+	keys, introduced := lesson.Characters(layout)
+	if len(introduced) == 0 {
+		return nil, false
+	}
+	fits := func(word string) bool {
+		for _, r := range word {
+			if !slices.Contains(keys, r) {
+				return false
+			}
+		}
+		return strings.ContainsAny(word, string(introduced))
+	}
+	id := LessonPackID(lesson, layout)
+	pack := &WordPack{ID: id, Name: lesson.Name + " lesson (" + layout.Name + ")"}
+	pack.Words = practiceWords(id, fits, func(rng *seededRand) string {
+		word := make([]rune, 3+rng.Intn(4))
+		for i := range word {
+			word[i] = keys[rng.Intn(len(keys))]
+		}
+		word[rng.Intn(len(word))] = introduced[rng.Intn(len(introduced))]
+		return string(word)
+	})
+	return pack, true
+}
+
+// Passes reports whether stats are good enough to pass the lesson
+func (l *Lesson) Passes(stats Stats) bool {
+	return stats.WPM >= l.MinWPM && stats.Accuracy >= l.MinAccuracy
+}
+
+// Goal returns the pass thresholds of the lesson in locale, e.g. "15 WPM at 90%"
+func (l *Lesson) Goal(locale *Locale) string {
+	return locale.Text("%.0f WPM at %.0f%%", l.MinWPM, l.MinAccuracy)
+}
+
+// lessonMode runs a lesson: a fixed number of words without scrolling,
+// graded by the thresholds of the lesson rather than ranked.
+type lessonMode struct {
+	survivalMode
+}
+
+func (lessonMode) ID() string             { return "lesson" }
+func (lessonMode) Name() string           { return "Lesson" }
+func (lessonMode) Scrolls() bool          { return false }
+func (lessonMode) Qualifies(g *Game) bool { return false }
+
+func (lessonMode) Description(l *Locale) string {
+	return l.Text("Type %d words of a lesson", lessonWords)
+}
+
+func (lessonMode) IsOver(g *Game) (bool, string) {
+	if g.WordsTyped >= lessonWords {
+		return true, "Lesson finished!"
+	}
+	return false, ""
+}
+
+func (lessonMode) Status(g *Game) string {
+	status := g.tr("Lesson: %d/%d", g.WordsTyped, lessonWords)
+	if lesson, ok := g.currentLesson(); ok {
+		status += " | " + g.tr("Pass: %s", lesson.Goal(g.Locale))
+	}
+	return status
+}
+
+// LessonResult is the best run of a lesson
+type LessonResult struct {
+	WPM      float64   `json:"wpm"`
+	Accuracy float64   `json:"accuracy"`
+	Passed   bool      `json:"passed"`
+	Date     time.Time `json:"date"`
+}
+
+// better reports whether r is a better result than other: a pass beats a
+// fail, then the faster run wins
+func (r LessonResult) better(other LessonResult) bool {
+	if r.Passed != other.Passed {
+		return r.Passed
+	}
+	return r.WPM > other.WPM
+}
+
+// LessonProgress keeps the best result of every lesson a profile has finished
+type LessonProgress struct {
+	Results map[string]LessonResult `json:"results"` // keyed by lesson ID
+	path    string
+}
+
+// LoadLessonProgress reads the lesson progress from path.
+// A missing file is not an error and yields no progress.
+func LoadLessonProgress(path string) (*LessonProgress, error) {
+	progress := &LessonProgress{Results: make(map[string]LessonResult), path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return progress, nil
+	}
+	if err != nil {
+		return progress, err
+	}
+	if err := json.Unmarshal(data, progress); err != nil {
+		return progress, err
+	}
+	if progress.Results == nil {
+		progress.Results = make(map[string]LessonResult)
+	}
+	return progress, nil
+}
+
+// Save writes the progress back to the file it was loaded from
+func (p *LessonProgress) Save() error {
+	if p.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(p.path, data, 0644)
+}
+
+// Record keeps result if it is the best of the lesson so far
+func (p *LessonProgress) Record(lesson *Lesson, result LessonResult) {
+	if best, ok := p.Results[lesson.ID]; !ok || result.better(best) {
+		p.Results[lesson.ID] = result
+	}
+}
+
+// Passed reports whether the lesson has been passed
+func (p *LessonProgress) Passed(lesson *Lesson) bool {
+	return p.Results[lesson.ID].Passed
+}
+
+// Unlocked reports whether the lesson can be played: the first one always
+// can, the others once the lesson before has been passed
+func (p *LessonProgress) Unlocked(lesson *Lesson) bool {
+	i := slices.Index(lessons, lesson)
+	return i == 0 || i > 0 && p.Passed(lessons[i-1])
+}
+
+// currentLesson returns the lesson the current run plays, false if it
+// isn't a lesson
+func (g *Game) currentLesson() (*Lesson, bool) {
+	if g.Mode.ID() != (lessonMode{}).ID() {
+		return nil, false
+	}
+	lesson, _, ok := parseLessonPackID(g.WordManager.Pack)
+	return lesson, ok
+}
+
+// startLesson starts a run of lesson on the active profile's layout
+func (g *Game) startLesson(lesson *Lesson) {
+	if g.Lessons != nil && !g.Lessons.Unlocked(lesson) {
+		previous := lessons[slices.Index(lessons, lesson)-1]
+		g.MenuMessage = g.tr("Pass %s to unlock %s", g.tr(previous.Name), g.tr(lesson.Name))
+		return
+	}
+	pack, ok := lessonPack(lesson, g.Layout)
+	if !ok {
+		g.MenuMessage = g.tr("The %s layout has no keys for the %s lesson", g.Layout.Name, g.tr(lesson.Name))
+		return
+	}
+	g.Mode = lessonMode{}
+	g.WordManager.SetPack(pack)
+	g.ClearGhost()
+	g.startRun()
+}
+
+// gradeLesson grades a finished lesson run and records the result in the
+// profile's lesson progress
+func (g *Game) gradeLesson() {
+	lesson, ok := g.currentLesson()
+	if !ok || g.WordsTyped < lessonWords {
+		return
+	}
+	stats := g.GetStats()
+	result := LessonResult{WPM: stats.WPM, Accuracy: stats.Accuracy, Passed: lesson.Passes(stats), Date: g.now()}
+	g.LessonResult = &result
+	if g.Lessons == nil {
+		return
+	}
+	g.Lessons.Record(lesson, result)
+	if err := g.Lessons.Save(); err != nil {
+		g.Logger.Printf("gradeLesson: failed to save lesson progress: %v", err)
+	}
+}
+
+// nextLesson returns the lesson after lesson in the curriculum, false for
+// the last one
+func nextLesson(lesson *Lesson) (*Lesson, bool) {
+	i := slices.Index(lessons, lesson)
+	if i < 0 || i+1 >= len(lessons) {
+		return nil, false
+	}
+	return lessons[i+1], true
+}
+
+// newLessonsMenu creates the lessons screen with the profile's progress.
+// It is rebuilt every time the screen is opened.
+func (g *Game) newLessonsMenu() *Menu {
+	menu := &Menu{Back: func() { g.State = StateMenu }}
+	for i, lesson := range lessons {
+		lesson := lesson
+		label := fmt.Sprintf("%d. %s", i+1, g.tr(lesson.Name))
+		if g.Lessons != nil {
+			switch result, ok := g.Lessons.Results[lesson.ID]; {
+			case !g.Lessons.Unlocked(lesson):
+				label += " - " + g.tr("locked")
+			case result.Passed:
+				label += " - " + g.tr("passed, best %.0f WPM at %.0f%%", result.WPM, result.Accuracy)
+			case ok:
+				label += " - " + g.tr("best %.0f WPM at %.0f%%", result.WPM, result.Accuracy)
+			}
+		}
+		menu.Items = append(menu.Items, MenuItem{Label: label, Shortcut: rune('1' + i),
+			Run: func(int) { g.startLesson(lesson) }})
+	}
+	menu.Items = append(menu.Items, MenuItem{Label: "Back", Shortcut: 'q', Run: func(int) { g.State = StateMenu }})
+	// Start on the furthest lesson unlocked
+	for i, lesson := range lessons {
+		if g.Lessons != nil && g.Lessons.Unlocked(lesson) {
+			menu.Selected = i
+		}
+	}
+	return menu
+}
+
+// openLessons shows the lessons screen
+func (g *Game) openLessons() {
+	g.lessonsMenu = g.newLessonsMenu()
+	g.State = StateLessons
+}
+
+// processLessonsInput handles the lessons screen
+func (g *Game) processLessonsInput(key rune) {
+	g.Logger.Debugf("processLessonsInput: key=%v", key)
+	g.MenuMessage = ""
+	g.lessonsMenu.ProcessInput(key)
+}
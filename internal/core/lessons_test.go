@@ -0,0 +1,147 @@
+package core
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLessonPacks(t *testing.T) {
+	for _, layout := range Layouts() {
+		for _, lesson := range Lessons() {
+			id := LessonPackID(lesson, layout)
+			pack, ok := WordPackByID(id)
+			if !ok || len(pack.Words) != drillWords {
+				t.Fatalf("Expected %d words for %s, got %v", drillWords, id, pack)
+			}
+			again, _ := lessonPack(lesson, layout)
+			if !slices.Equal(pack.Words, again.Words) {
+				t.Errorf("Expected %s to yield the same words every time", id)
+			}
+			keys, introduced := lesson.Characters(layout)
+			for _, word := range pack.Words {
+				if strings.IndexFunc(word, func(r rune) bool { return !slices.Contains(keys, r) }) >= 0 ||
+					!strings.ContainsAny(word, string(introduced)) {
+					t.Fatalf("%s: %q isn't made of the lesson's keys with a new one", id, word)
+				}
+			}
+		}
+	}
+
+	qwerty, _ := LayoutByID("qwerty")
+	if _, introduced := lessons[0].Characters(qwerty); string(introduced) != "adfghjkls" {
+		t.Errorf("Expected the QWERTY home row letters, got %q", string(introduced))
+	}
+	if _, introduced := lessons[4].Characters(qwerty); !strings.Contains(string(introduced), ";") || strings.ContainsAny(string(introduced), "aA1 ") {
+		t.Errorf("Expected only symbols in the symbols lesson, got %q", string(introduced))
+	}
+	for _, id := range []string{"lesson:home", "lesson:cursive:qwerty", "lesson:home:klingon"} {
+		if _, ok := WordPackByID(id); ok {
+			t.Errorf("Expected no pack for %s", id)
+		}
+	}
+}
+
+func TestLessonProgress(t *testing.T) {
+	path := filepath.Join(t.TempDir(), lessonsFile)
+	progress, err := LoadLessonProgress(path)
+	if err != nil {
+		t.Fatalf("LoadLessonProgress() error: %v", err)
+	}
+	if !progress.Unlocked(lessons[0]) || progress.Unlocked(lessons[1]) {
+		t.Fatal("Expected only the first lesson to be unlocked")
+	}
+
+	progress.Record(lessons[0], LessonResult{WPM: 30, Accuracy: 80})
+	progress.Record(lessons[0], LessonResult{WPM: 20, Accuracy: 95, Passed: true})
+	progress.Record(lessons[0], LessonResult{WPM: 40, Accuracy: 70})
+	if best := progress.Results["home"]; !best.Passed || best.WPM != 20 {
+		t.Errorf("Expected a pass to beat faster fails, got %+v", best)
+	}
+	if !progress.Unlocked(lessons[1]) || progress.Unlocked(lessons[2]) {
+		t.Error("Expected passing the first lesson to unlock the second only")
+	}
+
+	if err := progress.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	loaded, _ := LoadLessonProgress(path)
+	if !loaded.Passed(lessons[0]) || loaded.Passed(lessons[1]) {
+		t.Errorf("Expected the progress to be saved, got %+v", loaded.Results)
+	}
+}
+
+func TestLessonRun(t *testing.T) {
+	game := profileGame(t)
+	clock := NewManualClock(time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC))
+	game.Clock = clock
+
+	game.ProcessInput('e')
+	if game.State != StateLessons || !strings.Contains(game.Render(), "2. Top row - locked") {
+		t.Fatalf("Expected E to open the lessons with the second one locked, got state %v", game.State)
+	}
+	game.ProcessInput('2')
+	if game.State != StateLessons || !strings.Contains(game.MenuMessage, "unlock") {
+		t.Fatalf("Expected a locked lesson not to start, got state %v", game.State)
+	}
+
+	game.ProcessInput('1')
+	if game.State != StatePlaying || game.Mode.ID() != "lesson" || game.WordManager.Pack != "lesson:home:qwerty" {
+		t.Fatalf("Expected 1 to start the home row lesson, got %s with %s", game.Mode.ID(), game.WordManager.Pack)
+	}
+	for game.State == StatePlaying {
+		typeCurrentWord(game, false)
+		for f := 0; f < 60; f++ {
+			clock.Advance(time.Second / 60)
+			game.Render()
+		}
+	}
+	if game.WordsTyped != lessonWords || game.LessonResult == nil || !game.LessonResult.Passed {
+		t.Fatalf("Expected the lesson to be passed after %d words, got %d words and %+v", lessonWords, game.WordsTyped, game.LessonResult)
+	}
+	if frame := game.Render(); !strings.Contains(frame, "Home row passed! Top row is unlocked") {
+		t.Error("Expected the game over screen to show the pass")
+	}
+	if game.HighScoreRank != 0 {
+		t.Error("Expected lessons to stay out of the high scores")
+	}
+	if v, err := VerifyReplay(game.LastReplay); err != nil || v.Replayed != v.Claimed {
+		t.Errorf("Expected the lesson run to replay, got %+v, %v", v, err)
+	}
+
+	reopened, _ := game.Profiles.Open(game.Profile.Name)
+	if !reopened.Lessons.Passed(lessons[0]) {
+		t.Error("Expected the pass to be saved with the profile")
+	}
+	game.ProcessInput('m')
+	game.ProcessInput('e')
+	game.ProcessInput('2')
+	if game.State != StatePlaying || game.WordManager.Pack != "lesson:top:qwerty" {
+		t.Errorf("Expected the top row lesson to be unlocked, got state %v", game.State)
+	}
+}
+
+func TestLessonFailsBelowThresholds(t *testing.T) {
+	game := profileGame(t)
+	clock := NewManualClock(time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC))
+	game.Clock = clock
+	game.startLesson(lessons[0])
+	for game.State == StatePlaying {
+		typeCurrentWord(game, true)
+		for f := 0; f < 60; f++ {
+			clock.Advance(time.Second / 60)
+			game.Render()
+		}
+	}
+	if game.LessonResult == nil || game.LessonResult.Passed {
+		t.Fatalf("Expected the lesson to fail with a mistake every word, got %+v", game.LessonResult)
+	}
+	if game.Lessons.Unlocked(lessons[1]) {
+		t.Error("Expected a failed lesson not to unlock the next one")
+	}
+	if frame := game.Render(); !strings.Contains(frame, "Not passed yet, the goal is 15 WPM at 90%") {
+		t.Error("Expected the game over screen to show the goal")
+	}
+}
@@ -0,0 +1,104 @@
+package core
+
+import "time"
+
+const (
+	respawnInvulnerability = 2 * time.Second // grace period after a respawn
+	checkpointInterval     = 10              // every Nth platform is a checkpoint
+	maxLives               = 5
+)
+
+// livesOptions are the starting lives selectable in the menu, 0 disables lives
+var livesOptions = []int{0, 3, 5}
+
+// nextLivesOption returns the lives option that follows current
+func nextLivesOption(current int) int {
+	for i, lives := range livesOptions {
+		if lives == current {
+			return livesOptions[(i+1)%len(livesOptions)]
+		}
+	}
+	return livesOptions[0]
+}
+
+// LivesEnabled reports whether the current run uses the lives system
+func (g *Game) LivesEnabled() bool {
+	return g.StartingLives > 0
+}
+
+// handleFall is called when the player's platform scrolls off the bottom.
+// A shield or a spare life lets the player respawn, otherwise the run ends.
+func (g *Game) handleFall() {
+	switch {
+	case g.Invulnerable > 0:
+		// Falling while invulnerable is free, the player is just put back
+	case g.Shields > 0:
+		g.Shields--
+		g.Logger.Printf("handleFall: shield used, %d left", g.Shields)
+	case !g.LivesEnabled():
+		g.Logger.Println("handleFall: player fell off screen, game over")
+		g.endRun("You fell!")
+		return
+	default:
+		g.Lives--
+		g.Logger.Printf("handleFall: lost a life, %d left", g.Lives)
+		if g.Lives <= 0 {
+			g.endRun("Out of lives!")
+			return
+		}
+	}
+
+	if !g.respawn() {
+		g.endRun("Nowhere to respawn!")
+	}
+}
+
+// respawn moves the player onto the lowest visible incomplete platform,
+// grants a short invulnerability and eases the speed ramp back one step.
+func (g *Game) respawn() bool {
+	target := -1
+	for i, platform := range g.Platforms {
+		if platform.Complete || !platform.Standable() || platform.Y-1 >= g.Height-3 {
+			continue
+		}
+		if target == -1 || platform.Y > g.Platforms[target].Y {
+			target = i
+		}
+	}
+	if target == -1 {
+		return false
+	}
+
+	// If the platform is still above the screen, bring it into view
+	if g.Platforms[target].Y < 1 {
+		shift := g.Height/4 - g.Platforms[target].Y
+		for i := range g.Platforms {
+			g.Platforms[i].Y += shift
+		}
+	}
+
+	g.Player.Platform = target
+	g.Platforms[target].Typed = ""
+	g.Player.X = g.Platforms[target].X + g.Platforms[target].Width/2
+	g.Player.Y = g.Platforms[target].Y - 1
+	g.Invulnerable = respawnInvulnerability
+	g.activateWord()
+
+	// Undo one ramp step so the player isn't thrown straight back into the speed that beat them
+	g.ScrollSpeed /= g.Tuning.Speed.Factor
+	if g.ScrollSpeed < g.Tuning.Speed.Start {
+		g.ScrollSpeed = g.Tuning.Speed.Start
+	}
+	g.ScrollAccumulator = 0
+
+	g.Logger.Printf("respawn: player moved to platform %d, speed %.2f", target, g.ScrollSpeed)
+	return true
+}
+
+// reachCheckpoint restores a life when a checkpoint platform's word is completed
+func (g *Game) reachCheckpoint() {
+	if g.LivesEnabled() && g.Lives < maxLives {
+		g.Lives++
+		g.Logger.Printf("reachCheckpoint: life restored, %d left", g.Lives)
+	}
+}
@@ -0,0 +1,97 @@
+package core
+
+import (
+	"testing"
+)
+
+// dropPlayer moves the player's platform below the fall line
+func dropPlayer(game *Game) {
+	game.Platforms[game.Player.Platform].Y = game.Height
+}
+
+func TestFallWithoutLivesEndsRun(t *testing.T) {
+	game := newPlayingGame(t)
+
+	dropPlayer(game)
+	game.Render()
+
+	if game.State != StateGameOver {
+		t.Errorf("Expected fall to end the run, got state %v", game.State)
+	}
+}
+
+func TestFallWithLivesRespawns(t *testing.T) {
+	game := newPlayingGame(t, func(g *Game) { g.StartingLives = 3 })
+	fallen := game.Player.Platform
+	game.ScrollSpeed = initialScrollSpeed * speedIncreaseFactor * speedIncreaseFactor
+
+	dropPlayer(game)
+	game.Render()
+
+	if game.State != StatePlaying {
+		t.Fatalf("Expected run to continue after respawn, got state %v", game.State)
+	}
+	if game.Lives != 2 {
+		t.Errorf("Expected 2 lives left, got %d", game.Lives)
+	}
+	if game.Player.Platform == fallen {
+		t.Error("Expected player to respawn on a different platform")
+	}
+	if game.Player.Y >= game.Height-3 || game.Player.Y < 0 {
+		t.Errorf("Expected respawn on a visible platform, got Y=%d", game.Player.Y)
+	}
+	if game.Invulnerable <= 0 {
+		t.Error("Expected invulnerability after respawn")
+	}
+	if game.ScrollSpeed >= initialScrollSpeed*speedIncreaseFactor*speedIncreaseFactor {
+		t.Errorf("Expected speed ramp to ease off after respawn, got %.2f", game.ScrollSpeed)
+	}
+
+	// A second fall during invulnerability does not cost a life
+	dropPlayer(game)
+	game.Render()
+	if game.Lives != 2 {
+		t.Errorf("Expected invulnerable fall to keep 2 lives, got %d", game.Lives)
+	}
+}
+
+func TestLastLifeEndsRun(t *testing.T) {
+	game := newPlayingGame(t, func(g *Game) { g.StartingLives = 3 })
+	game.Lives = 1
+
+	dropPlayer(game)
+	game.Render()
+
+	if game.State != StateGameOver {
+		t.Errorf("Expected losing the last life to end the run, got state %v", game.State)
+	}
+}
+
+func TestCheckpointRestoresLife(t *testing.T) {
+	game := newPlayingGame(t, func(g *Game) { g.StartingLives = 3 })
+	game.Lives = 1
+
+	platform := &game.Platforms[game.Player.Platform]
+	platform.Checkpoint = true
+	for _, ch := range platform.Word {
+		game.ProcessInput(ch)
+	}
+
+	if game.Lives != 2 {
+		t.Errorf("Expected checkpoint to restore a life, got %d lives", game.Lives)
+	}
+}
+
+func TestCheckpointsGenerated(t *testing.T) {
+	game := newPlayingGame(t, func(g *Game) { g.StartingLives = 3 })
+
+	checkpoints := 0
+	for i := 0; i < checkpointInterval*3; i++ {
+		if game.newPlatform(0, 0, 10).Checkpoint {
+			checkpoints++
+		}
+	}
+	if checkpoints != 3 {
+		t.Errorf("Expected 3 checkpoints in %d platforms, got %d", checkpointInterval*3, checkpoints)
+	}
+}
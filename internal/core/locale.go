@@ -0,0 +1,52 @@
+package core
+
+import "fmt"
+
+// Locale is a language of the user interface. Messages are looked up by
+// their English text, so English needs no translations and every message a
+// locale doesn't translate is shown in English.
+type Locale struct {
+	ID           string            // ISO 639-1 code, used in the config file
+	Name         string            // name of the language in the language itself
+	Translations map[string]string // by English text, formats keep the English verbs
+}
+
+// locales holds every locale in menu order; the first one is the default
+var locales = []*Locale{
+	{ID: "en", Name: "English"},
+	{ID: "de", Name: "Deutsch", Translations: germanMessages},
+}
+
+// Locales returns every locale in menu order
+func Locales() []*Locale {
+	return locales
+}
+
+// LocaleByID looks up a locale by its ID
+func LocaleByID(id string) (*Locale, bool) {
+	for _, locale := range locales {
+		if locale.ID == id {
+			return locale, true
+		}
+	}
+	return nil, false
+}
+
+// Text returns the translation of the English message msg, formatted with
+// args if there are any. A nil locale is English.
+func (l *Locale) Text(msg string, args ...any) string {
+	if l != nil {
+		if translation, ok := l.Translations[msg]; ok {
+			msg = translation
+		}
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// tr translates a message into the game's locale
+func (g *Game) tr(msg string, args ...any) string {
+	return g.Locale.Text(msg, args...)
+}
@@ -0,0 +1,227 @@
+package core
+
+// germanMessages translates the user interface into German
+var germanMessages = map[string]string{
+	"%.0f WPM at %.0f%%":  "%.0f WPM bei %.0f%%",
+	"%.1f WPM  (%.0f%%)":  "%.1f WPM  (%.0f%%)",
+	"%d WORD STREAK!":     "%d WÖRTER AM STÜCK!",
+	"%d pts  (%.0f WPM)":  "%d Pkt.  (%.0f WPM)",
+	"%s  (%.0f WPM)":      "%s  (%.0f WPM)",
+	"%s by day (average)": "%s pro Tag (Durchschnitt)",
+	"%s is unlocked":      "%s ist freigeschaltet",
+	"%s passed!":          "%s bestanden!",
+	"%s play again | %s retry this course | T timeline | M menu | %s quit": "%s nochmal | %s Strecke wiederholen | T Verlauf | M Menü | %s beenden",
+	"(Complete!)": "(Fertig!)",
+	"(finished)":  "(im Ziel)",
+	"(safe)":      "(geschützt)",
+	"* WPM over %ds | ^ speed ramp (%d) | x mistake cluster (%d)": "* WPM über %ds | ^ Tempostufe (%d) | x Fehlerhäufung (%d)",
+	"1-%d start a drill | L change layout | ESC back":             "1-%d Übung starten | L Layout wechseln | ESC zurück",
+	"A tie with the ghost":    "Unentschieden gegen den Geist",
+	"ASCII TYPING PLATFORMER": "ASCII-TIPP-PLATTFORMER",
+	"Acc":                     "Gen.",
+	"Accents":                 "Akzente",
+	"Accuracy":                "Genauigkeit",
+	"Accuracy: %.1f%%":        "Genauigkeit: %.1f%%",
+	"All modes":               "Alle Modi",
+	"Allow errors":            "Fehler erlauben",
+	"Any":                     "Beliebig",
+	"Back":                    "Zurück",
+	"Base %d + Speed %d + Combo %d + Streak %d + Power-ups %d": "Basis %d + Tempo %d + Combo %d + Serie %d + Power-ups %d",
+	"Best combo: %d words":               "Beste Combo: %d Wörter",
+	"Bottom row":                         "Untere Reihe",
+	"CPM: %.1f":                          "ZPM: %.1f",
+	"Can't load the personal best: %s":   "Die Bestleistung lässt sich nicht laden: %s",
+	"Can't switch profiles: %s":          "Profilwechsel nicht möglich: %s",
+	"Challenge code: %s":                 "Herausforderungscode: %s",
+	"Challenge loaded: %s":               "Herausforderung geladen: %s",
+	"Challenge: %s":                      "Herausforderung: %s",
+	"Classic":                            "Klassisch",
+	"Code: %s_ (ENTER load, ESC cancel)": "Code: %s_ (ENTER laden, ESC abbrechen)",
+	"Common Words":                       "Häufige Wörter",
+	"Continue saved run":                 "Gespeicherten Lauf fortsetzen",
+	"Created %s":                         "%s angelegt",
+	"Custom":                             "Eigene",
+	"DAILY CHALLENGE":                    "TAGESHERAUSFORDERUNG",
+	"Daily %s":                           "Tagesherausforderung: %s",
+	"Daily challenge":                    "Tagesherausforderung",
+	"Daily history":                      "Tagesverlauf",
+	"Daily result recorded!":             "Tagesergebnis gespeichert!",
+	"Delete %s and all of its scores? Y to confirm, any other key to cancel": "%s mit allen Ergebnissen löschen? Y bestätigt, jede andere Taste bricht ab",
+	"Delete character":                       "Zeichen löschen",
+	"Delete word":                            "Wort löschen",
+	"Deleted %s":                             "%s gelöscht",
+	"Difficulty":                             "Schwierigkeit",
+	"Easy":                                   "Leicht",
+	"Endless climb, the speed keeps ramping": "Endloser Aufstieg, das Tempo steigt immer weiter",
+	"Enter challenge code":                   "Herausforderungscode eingeben",
+	"Errors":                                 "Fehler",
+	"Exact":                                  "Genau",
+	"Export failed: %s":                      "Export fehlgeschlagen: %s",
+	"Exported to %s":                         "Exportiert nach %s",
+	"Failed to save the run: %s":             "Lauf konnte nicht gespeichert werden: %s",
+	"Finished!":                              "Geschafft!",
+	"Fri":                                    "Fr",
+	"GAME OVER":                              "SPIEL VORBEI",
+	"Ghost: %+d":                             "Geist: %+d",
+	"Goal: %d/%d":                            "Ziel: %d/%d",
+	"Hand alternation":                       "Handwechsel",
+	"Hard":                                   "Schwer",
+	"Home and bottom rows":                   "Grund- und Unterreihe",
+	"Home and top rows":                      "Grund- und Oberreihe",
+	"Home row":                               "Grundreihe",
+	"Ignored":                                "Ignoriert",
+	"Import file: ":                          "Datei importieren: ",
+	"Imported %s":                            "%s importiert",
+	"Insane":                                 "Wahnsinn",
+	"KEYBOARD":                               "TASTATUR",
+	"Key bindings not saved: %s":             "Tastenbelegung nicht gespeichert: %s",
+	"Key: Delete character":                  "Taste: Zeichen löschen",
+	"Key: Delete word":                       "Taste: Wort löschen",
+	"Key: Pause":                             "Taste: Pause",
+	"Key: Quit":                              "Taste: Beenden",
+	"Key: Resume":                            "Taste: Fortsetzen",
+	"Key: Retry course":                      "Taste: Strecke wiederholen",
+	"Key: Skip word":                         "Taste: Wort überspringen",
+	"Key: Start":                             "Taste: Start",
+	"Keyboard and drills":                    "Tastatur und Übungen",
+	"LESSONS":                                "LEKTIONEN",
+	"Language":                               "Sprache",
+	"Last %d of %d sessions":                 "Letzte %d von %d Läufen",
+	"Layout":                                 "Layout",
+	"Layout: %s":                             "Layout: %s",
+	"Left hand":                              "Linke Hand",
+	"Left hand: %.1f%% | Right hand: %.1f%% | Weakest: %s %.1f%%": "Linke Hand: %.1f%% | Rechte Hand: %.1f%% | Am schwächsten: %s %.1f%%",
+	"Left: %s":         "Rest: %s",
+	"Lesson":           "Lektion",
+	"Lesson finished!": "Lektion beendet!",
+	"Lesson: %d/%d":    "Lektion: %d/%d",
+	"Lessons":          "Lektionen",
+	"Light background": "Heller Hintergrund",
+	"Lives":            "Leben",
+	"Lives: %s":        "Leben: %s",
+	"M mode | N sessions | C chart | E export | ESC back": "M Modus | N Läufe | C Diagramm | E exportieren | ESC zurück",
+	"M mode | P pack | D difficulty | L lives":            "M Modus | P Wortpaket | D Schwierigkeit | L Leben",
+	"Marks":                    "Marken",
+	"Mode":                     "Modus",
+	"Mode: < %s >":             "Modus: < %s >",
+	"Mon":                      "Mo",
+	"Monochrome":               "Einfarbig",
+	"New high score! Rank #%d": "Neuer Rekord! Platz %d",
+	"New keys: %s":             "Neue Tasten: %s",
+	"New profile: ":            "Neues Profil: ",
+	"No active word":           "Kein aktives Wort",
+	"No finished runs yet - play one and come back": "Noch keine beendeten Läufe - spiel einen und komm wieder",
+	"No key pairs typed yet":                        "Noch keine Tastenpaare getippt",
+	"No keys typed yet":                             "Noch keine Tasten getippt",
+	"No personal best replay for %s yet":            "Noch keine Aufzeichnung der Bestleistung für %s",
+	"No scrolling, no pressure, just typing stats":  "Kein Scrollen, kein Druck, nur Tippstatistik",
+	"Normal":                                "Normal",
+	"Not passed yet, the goal is %s":        "Noch nicht bestanden, das Ziel ist %s",
+	"Nowhere to respawn!":                   "Kein Platz zum Wiedereinstieg!",
+	"Numbers":                               "Zahlen",
+	"One wrong key and it's over":           "Eine falsche Taste und es ist vorbei",
+	"Out of lives!":                         "Keine Leben mehr!",
+	"PAUSED":                                "PAUSE",
+	"PROFILES":                              "PROFILE",
+	"PROGRESS":                              "FORTSCHRITT",
+	"Pack: %s | Difficulty: %s | Lives: %s": "Wortpaket: %s | Schwierigkeit: %s | Leben: %s",
+	"Pairs: alternating %s | same hand %s | same finger %s": "Paare: Handwechsel %s | gleiche Hand %s | gleicher Finger %s",
+	"Pass %s to unlock %s":   "Bestehe %s, um %s freizuschalten",
+	"Pass: %s":               "Bestehen: %s",
+	"Pass: %s over %d words": "Bestehen: %s über %d Wörter",
+	"Pause":                  "Pause",
+	"Player: %s":             "Spieler: %s",
+	"Playing as %s":          "Du spielst als %s",
+	"Practice run - today's daily was already played": "Trainingslauf - die heutige Herausforderung ist schon gespielt",
+	"Press %s to resume, ENTER to end run":            "%s setzt fort, ENTER beendet den Lauf",
+	"Press any key to return to the menu":             "Eine beliebige Taste kehrt zum Menü zurück",
+	"Press the new key for %s":                        "Neue Taste für %s drücken",
+	"Profiles":                                        "Profile",
+	"Programming":                                     "Programmierung",
+	"Progress":                                        "Fortschritt",
+	"Quit":                                            "Beenden",
+	"RUN TIMELINE":                                    "LAUFVERLAUF",
+	"Race your best":                                  "Gegen die Bestleistung",
+	"Racing ghost: %s (%d words, %d points)":          "Rennen gegen den Geist: %s (%d Wörter, %d Punkte)",
+	"Random course":                                   "Zufällige Strecke",
+	"Rename to: ":                                     "Umbenennen in: ",
+	"Renamed to %s":                                   "Umbenannt in %s",
+	"Replay saved to %s":                              "Aufzeichnung gespeichert unter %s",
+	"Reset keys":                                      "Tasten zurücksetzen",
+	"Resume":                                          "Fortsetzen",
+	"Retry course":                                    "Strecke wiederholen",
+	"Right hand":                                      "Rechte Hand",
+	"Run ended":                                       "Lauf beendet",
+	"S to save and return to the menu, %s to save and quit": "S speichert und kehrt zum Menü zurück, %s speichert und beendet",
+	"SETTINGS":                       "EINSTELLUNGEN",
+	"Sat":                            "Sa",
+	"Saved run: %s, %d points":       "Gespeicherter Lauf: %s, %d Punkte",
+	"Score":                          "Punkte",
+	"Score as much as you can in %s": "Sammle in %s so viele Punkte wie möglich",
+	"Score: %d":                      "Punkte: %d",
+	"Settings":                       "Einstellungen",
+	"Settings not saved: %s":         "Einstellungen nicht gespeichert: %s",
+	"Skip errors":                    "Fehler überspringen",
+	"Skip word":                      "Wort überspringen",
+	"Speed":                          "Tempo",
+	"Start":                          "Start",
+	"Stop on error":                  "Bei Fehler anhalten",
+	"Streak: %d":                     "Serie: %d",
+	"Streak: %d | Best streak: %d | Days played: %d": "Serie: %d | Beste Serie: %d | Gespielte Tage: %d",
+	"Sudden Death": "Sudden Death",
+	"Sun":          "So",
+	"Survival":     "Überleben",
+	"Symbols":      "Symbole",
+	"T results | %s play again | %s retry this course | M menu | %s quit": "T Ergebnis | %s nochmal | %s Strecke wiederholen | M Menü | %s beenden",
+	"The %s layout has no keys for the %s lesson":                         "Das Layout %s hat keine Tasten für die Lektion %s",
+	"The %s layout has no letters for the %s drill":                       "Das Layout %s hat keine Buchstaben für die Übung %s",
+	"The ghost won by %d words":                                           "Der Geist hat mit %d Wörtern gewonnen",
+	"The run was too short for a timeline":                                "Der Lauf war zu kurz für einen Verlauf",
+	"The saved run can't be continued: %s":                                "Der gespeicherte Lauf kann nicht fortgesetzt werden: %s",
+	"Theme":                                                               "Farbschema",
+	"Thu":                                                                 "Do",
+	"Time's up!":                                                          "Zeit abgelaufen!",
+	"Time: %s":                                                            "Zeit: %s",
+	"Today: %d points | %.1f WPM | %.1f%% accuracy | %d words": "Heute: %d Punkte | %.1f WPM | %.1f%% Genauigkeit | %d Wörter",
+	"Today: not played yet - press Y in the menu":              "Heute: noch nicht gespielt - Y im Menü drücken",
+	"Top row":                          "Obere Reihe",
+	"Tue":                              "Di",
+	"Type %d words as fast as you can": "Tippe %d Wörter so schnell du kannst",
+	"Type %d words of a lesson":        "Tippe %d Wörter einer Lektion",
+	"Type a name, ENTER to create, ESC to cancel":                                                  "Namen tippen, ENTER legt an, ESC bricht ab",
+	"Type a name, ENTER to rename, ESC to cancel":                                                  "Namen tippen, ENTER benennt um, ESC bricht ab",
+	"Type the path of an exported profile, ENTER to import, ESC to cancel":                         "Pfad eines exportierten Profils tippen, ENTER importiert, ESC bricht ab",
+	"UP/DOWN select | ENTER confirm | ESC quit":                                                    "NACH OBEN/UNTEN wählen | ENTER bestätigen | ESC beenden",
+	"UP/DOWN select | ENTER or 1-%d start | ESC back":                                              "NACH OBEN/UNTEN wählen | ENTER oder 1-%d startet | ESC zurück",
+	"UP/DOWN select | ENTER switch | N new | R rename | X delete | E export | I import | ESC back": "NACH OBEN/UNTEN wählen | ENTER wechseln | N neu | R umbenennen | X löschen | E exportieren | I importieren | ESC zurück",
+	"UP/DOWN select | LEFT/RIGHT or ENTER change | ESC back":                                       "NACH OBEN/UNTEN wählen | LINKS/RECHTS oder ENTER ändert | ESC zurück",
+	"Unknown game state": "Unbekannter Spielzustand",
+	"WPM":                "WPM",
+	"WPM: %.1f":          "WPM: %.1f",
+	"WPM: %.1f | CPM: %.1f | Acc: %.1f%% | Words: %d | Combo: %d (x%.1f)": "WPM: %.1f | ZPM: %.1f | Gen.: %.1f%% | Wörter: %d | Combo: %d (x%.1f)",
+	"Wed":                             "Mi",
+	"Word pack":                       "Wortpaket",
+	"Word: %s":                        "Wort: %s",
+	"Words: %d":                       "Wörter: %d",
+	"Wrong key!":                      "Falsche Taste!",
+	"You beat the ghost by %d words":  "Du hast den Geist um %d Wörter geschlagen",
+	"You fell!":                       "Abgestürzt!",
+	"Zen":                             "Zen",
+	"active":                          "aktiv",
+	"below 90%":                       "unter 90%",
+	"best %.0f WPM at %.0f%%":         "beste %.0f WPM bei %.0f%%",
+	"last %s best %s":                 "letzte %s beste %s",
+	"left index":                      "linker Zeigefinger",
+	"left middle":                     "linker Mittelfinger",
+	"left pinky":                      "linker kleiner Finger",
+	"left ring":                       "linker Ringfinger",
+	"locked":                          "gesperrt",
+	"not typed yet":                   "noch nicht getippt",
+	"off":                             "aus",
+	"passed, best %.0f WPM at %.0f%%": "bestanden, beste %.0f WPM bei %.0f%%",
+	"right index":                     "rechter Zeigefinger",
+	"right middle":                    "rechter Mittelfinger",
+	"right pinky":                     "rechter kleiner Finger",
+	"right ring":                      "rechter Ringfinger",
+	"unknown":                         "unbekannt",
+}
@@ -0,0 +1,197 @@
+package core
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// uiMessages collects the English text of every message the game shows: the
+// literals passed to tr, Text and endRun, menu labels, the names and end
+// reasons returned as literals, and the names of the catalogs
+func uiMessages(t *testing.T) map[string]bool {
+	t.Helper()
+	messages := make(map[string]bool)
+	add := func(lit ast.Expr) {
+		if lit, ok := lit.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			if text, err := strconv.Unquote(lit.Value); err == nil && text != "" {
+				messages[text] = true
+			}
+		}
+	}
+
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") || strings.HasPrefix(path, "locale") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.CallExpr:
+				var name string
+				switch fun := n.Fun.(type) {
+				case *ast.Ident:
+					name = fun.Name
+				case *ast.SelectorExpr:
+					name = fun.Sel.Name
+				}
+				if (name == "tr" || name == "Text" || name == "endRun") && len(n.Args) > 0 {
+					add(n.Args[0])
+				}
+			case *ast.KeyValueExpr:
+				if key, ok := n.Key.(*ast.Ident); ok && key.Name == "Label" {
+					add(n.Value)
+				}
+			case *ast.FuncDecl:
+				if n.Name.Name == "Name" || n.Name.Name == "IsOver" {
+					ast.Inspect(n.Body, func(n ast.Node) bool {
+						if ret, ok := n.(*ast.ReturnStmt); ok {
+							for _, result := range ret.Results {
+								add(result)
+							}
+						}
+						return true
+					})
+				}
+			}
+			return true
+		})
+	}
+
+	names := append([]string{livesName(0), accentsName(false), accentsName(true), presetName(0)}, fingerNames...)
+	for _, preset := range presets {
+		names = append(names, preset.Name)
+	}
+	for _, theme := range themes {
+		names = append(names, theme.Name)
+	}
+	for _, pack := range wordPacks {
+		if pack.Language == "en" {
+			names = append(names, pack.Name)
+		}
+	}
+	for _, drill := range drills {
+		names = append(names, drill.Name)
+	}
+	for _, lesson := range lessons {
+		names = append(names, lesson.Name)
+	}
+	for _, metric := range progressMetrics {
+		names = append(names, metric.Name)
+	}
+	for _, info := range actions {
+		names = append(names, info.Name, "Key: "+info.Name)
+	}
+	for day := 0; day < 7; day++ {
+		names = append(names, time.Weekday(day).String()[:3])
+	}
+	for _, name := range names {
+		messages[name] = true
+	}
+	return messages
+}
+
+// formatVerbs matches the fmt verbs of a message
+var formatVerbs = regexp.MustCompile(`%[-+# 0]*[0-9]*(\.[0-9]+)?[a-zA-Z%]`)
+
+func TestTranslations(t *testing.T) {
+	messages := uiMessages(t)
+	if len(messages) < 100 || !messages["GAME OVER"] || !messages["Survival"] || !messages["You fell!"] ||
+		!messages["Settings"] || !messages["Score: %d"] {
+		t.Fatalf("Expected the messages of every screen, got %d", len(messages))
+	}
+
+	for _, locale := range locales[1:] {
+		var missing []string
+		for msg := range messages {
+			if _, ok := locale.Translations[msg]; !ok {
+				missing = append(missing, msg)
+			}
+		}
+		sort.Strings(missing)
+		for _, msg := range missing {
+			t.Errorf("%s: no translation of %q, shown in English", locale.ID, msg)
+		}
+
+		for msg, translation := range locale.Translations {
+			if !messages[msg] {
+				t.Errorf("%s: %q is translated but never shown", locale.ID, msg)
+			}
+			if want, got := formatVerbs.FindAllString(msg, -1), formatVerbs.FindAllString(translation, -1); !slices.Equal(want, got) {
+				t.Errorf("%s: %q has the verbs %v, expected %v", locale.ID, translation, got, want)
+			}
+		}
+	}
+}
+
+func TestLocaleText(t *testing.T) {
+	var english *Locale
+	if got := english.Text("Score: %d", 12); got != "Score: 12" {
+		t.Errorf("Expected a nil locale to be English, got %q", got)
+	}
+	partial := &Locale{ID: "xx", Translations: map[string]string{"Score: %d": "Punkte: %d"}}
+	if got := partial.Text("Score: %d", 12); got != "Punkte: 12" {
+		t.Errorf("Expected the translation, got %q", got)
+	}
+	if got := partial.Text("Words: %d", 3); got != "Words: 3" {
+		t.Errorf("Expected a missing translation to fall back to English, got %q", got)
+	}
+	if got := partial.Text("100%"); got != "100%" {
+		t.Errorf("Expected messages without arguments to stay unformatted, got %q", got)
+	}
+
+	for _, tt := range []struct {
+		text  string
+		width int
+	}{{"Score", 5}, {"Größe", 5}, {"日本語", 6}, {"é", 1}} {
+		if got := textWidth(tt.text); got != tt.width {
+			t.Errorf("textWidth(%q) = %d, want %d", tt.text, got, tt.width)
+		}
+	}
+}
+
+func TestLocalizedScreens(t *testing.T) {
+	game := newMenuGame(t)
+	german, _ := LocaleByID("de")
+	game.Locale = german
+
+	frame := game.Render()
+	for _, text := range []string{"Tagesherausforderung (Y)", "Überleben", "NACH OBEN/UNTEN"} {
+		if !strings.Contains(frame, text) {
+			t.Errorf("Expected the German menu to show %q", text)
+		}
+	}
+	// Titles are centered by their width on screen, not their bytes
+	title := german.Text("ASCII TYPING PLATFORMER")
+	if want := "\033[5;" + strconv.Itoa(40-textWidth(title)/2+1) + "H"; !strings.Contains(frame, want+ColorBold+ColorCyan+title) {
+		t.Errorf("Expected %q centered at column %d", title, 40-textWidth(title)/2)
+	}
+
+	game.ProcessInput(' ')
+	if frame := game.Render(); !strings.Contains(frame, "Punkte: 0") {
+		t.Error("Expected a German HUD")
+	}
+	game.endRun("You fell!")
+	if frame := game.Render(); !strings.Contains(frame, "SPIEL VORBEI") || !strings.Contains(frame, "Abgestürzt!") {
+		t.Error("Expected a German game over screen")
+	}
+	if game.EndReason != "You fell!" {
+		t.Errorf("Expected the end reason to be kept in English for replays and history, got %q", game.EndReason)
+	}
+}
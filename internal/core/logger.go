@@ -1,22 +1,68 @@
 package core
 
 import (
+	"fmt"
+	"io"
 	"log"
 	"os"
 )
 
+// LogLevel is how much the game logs
+type LogLevel int
+
+const (
+	LogOff   LogLevel = iota // nothing, no log file is created
+	LogInfo                  // run events and errors
+	LogDebug                 // also every key press and platform jump
+)
+
+// ParseLogLevel parses "off", "info" or "debug"
+func ParseLogLevel(s string) (LogLevel, error) {
+	switch s {
+	case "off":
+		return LogOff, nil
+	case "info":
+		return LogInfo, nil
+	case "debug":
+		return LogDebug, nil
+	}
+	return LogOff, fmt.Errorf("%w: unknown log level %q, expected off, info or debug", ErrInvalidConfig, s)
+}
+
 // Logger wraps a standard logger for file logging
 // This abstraction allows us to swap out logging implementations if needed.
 type Logger struct {
 	*log.Logger
+	level LogLevel
 }
 
-// NewLogger creates a new logger that writes to the specified file path.
+// NewLogger creates a new logger that writes everything to the specified file path.
 func NewLogger(filePath string) (*Logger, error) {
+	return NewLevelLogger(filePath, LogDebug)
+}
+
+// NewLevelLogger creates a logger that writes messages up to level to the
+// specified file path
+func NewLevelLogger(filePath string, level LogLevel) (*Logger, error) {
+	if level == LogOff {
+		return &Logger{log.New(io.Discard, "", 0), LogOff}, nil
+	}
 	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0666)
 	if err != nil {
 		return nil, err
 	}
 	logger := log.New(file, "[ascii-type] ", log.LstdFlags|log.Lshortfile)
-	return &Logger{logger}, nil
+	return &Logger{logger, level}, nil
+}
+
+// newDiscardLogger creates a logger that drops everything, for headless games
+func newDiscardLogger() *Logger {
+	return &Logger{log.New(io.Discard, "", 0), LogOff}
+}
+
+// Debugf logs chatty details, only at LogDebug
+func (l *Logger) Debugf(format string, v ...any) {
+	if l.level >= LogDebug {
+		l.Output(2, fmt.Sprintf(format, v...))
+	}
 }
@@ -0,0 +1,182 @@
+package core
+
+import (
+	"slices"
+	"strings"
+)
+
+// markovOrder is how many characters the models look back
+const markovOrder = 2
+
+// maxPseudoWordLength is the longest pseudo-word made up when no length is given
+const maxPseudoWordLength = 10
+
+// pseudoAttempts is how many words PseudoWords makes up before giving up on
+// its constraints
+const pseudoAttempts = 100
+
+// pseudoPrefix starts the pack IDs of pseudo-words, "pseudo:<pack>"
+const pseudoPrefix = "pseudo:"
+
+// Markers around the words a model is trained on; they can't be typed
+const (
+	wordStart = '\x02'
+	wordEnd   = '\x03'
+)
+
+// WordSource makes up words instead of drawing them from a list
+type WordSource interface {
+	// Word returns a word of minLength to maxLength characters, 0 for any,
+	// drawing only from rng. It returns false if it can't make one up.
+	Word(rng *seededRand, minLength, maxLength int) (string, bool)
+}
+
+// MarkovModel is a character-level Markov chain trained on a word list. It
+// counts which characters follow every context of up to Order characters,
+// so generation can back off to shorter contexts.
+type MarkovModel struct {
+	Order  int
+	counts map[string]map[rune]int // keyed by context, then by the next character
+}
+
+// TrainMarkov trains a model of the given order on words
+func TrainMarkov(words []string, order int) *MarkovModel {
+	m := &MarkovModel{Order: order, counts: make(map[string]map[rune]int)}
+	for _, word := range words {
+		padded := []rune(strings.Repeat(string(wordStart), order) + strings.ToLower(word) + string(wordEnd))
+		for i := order; i < len(padded); i++ {
+			for k := 0; k <= order; k++ {
+				context := string(padded[i-k : i])
+				if m.counts[context] == nil {
+					m.counts[context] = make(map[rune]int)
+				}
+				m.counts[context][padded[i]]++
+			}
+		}
+	}
+	return m
+}
+
+// next draws the character that follows history, among the ones keep
+// allows, from the longest context that has any. It returns false if no
+// context has one.
+func (m *MarkovModel) next(rng *seededRand, history []rune, keep func(rune) bool) (rune, bool) {
+	for k := min(m.Order, len(history)); k >= 0; k-- {
+		followers := m.counts[string(history[len(history)-k:])]
+		var candidates []rune
+		total := 0
+		for r, count := range followers {
+			if keep(r) {
+				candidates = append(candidates, r)
+				total += count
+			}
+		}
+		if total == 0 {
+			continue
+		}
+		slices.Sort(candidates) // Map order is random, the draws must not be
+		pick := rng.Intn(total)
+		for _, r := range candidates {
+			if pick -= followers[r]; pick < 0 {
+				return r, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// PseudoWords makes up pronounceable words with a Markov model, within
+// constraints on the letters, the length and the bigrams they contain
+type PseudoWords struct {
+	Model     *MarkovModel
+	Letters   string   // letters the words may use, empty for any
+	MinLength int      // shortest word, 0 for any
+	MaxLength int      // longest word, 0 for maxPseudoWordLength
+	Bigrams   []string // every word contains at least one of them, none for any
+}
+
+// Word makes up a word within both the source's and the given length band
+func (p *PseudoWords) Word(rng *seededRand, minLength, maxLength int) (string, bool) {
+	lo := max(p.MinLength, minLength, 1)
+	hi := maxPseudoWordLength
+	for _, limit := range []int{p.MaxLength, maxLength} {
+		if limit > 0 {
+			hi = min(hi, limit)
+		}
+	}
+	if lo > hi {
+		return "", false
+	}
+
+	for attempt := 0; attempt < pseudoAttempts; attempt++ {
+		word, ok := p.generate(rng, lo, hi)
+		if ok && p.hasBigram(word) {
+			return word, true
+		}
+	}
+	return "", false
+}
+
+// generate makes up a word of lo to hi characters, ignoring the bigrams
+func (p *PseudoWords) generate(rng *seededRand, lo, hi int) (string, bool) {
+	history := []rune(strings.Repeat(string(wordStart), p.Model.Order))
+	var word []rune
+	for len(word) < hi {
+		r, ok := p.Model.next(rng, history, func(r rune) bool {
+			if r == wordEnd {
+				return len(word) >= lo
+			}
+			return p.Letters == "" || strings.ContainsRune(p.Letters, r)
+		})
+		if !ok {
+			return "", false
+		}
+		if r == wordEnd {
+			break
+		}
+		word = append(word, r)
+		history = append(history, r)
+	}
+	return string(word), len(word) >= lo
+}
+
+// hasBigram reports whether word contains one of the required bigrams
+func (p *PseudoWords) hasBigram(word string) bool {
+	if len(p.Bigrams) == 0 {
+		return true
+	}
+	for _, bigram := range p.Bigrams {
+		if strings.Contains(word, bigram) {
+			return true
+		}
+	}
+	return false
+}
+
+// bundledModel returns a model trained on the words of every bundled English pack
+func bundledModel() *MarkovModel {
+	var words []string
+	for _, pack := range wordPacks {
+		if pack.Language == "en" {
+			words = append(words, pack.Words...)
+		}
+	}
+	return TrainMarkov(words, markovOrder)
+}
+
+// pseudoPackByID builds the pack of pseudo-words of a pack, "pseudo:<pack>".
+// The pack's own words are drawn from when no word can be made up.
+func pseudoPackByID(id string) (*WordPack, bool) {
+	for _, pack := range wordPacks {
+		if pseudoPrefix+pack.ID == id {
+			return &WordPack{
+				ID:       id,
+				Name:     pack.Name + " (pseudo-words)",
+				Language: pack.Language,
+				Words:    pack.Words,
+				Source:   &PseudoWords{Model: TrainMarkov(pack.Words, markovOrder)},
+			}, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,174 @@
+package core
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func pseudoWords(source WordSource, seed int64, n, minLength, maxLength int) []string {
+	rng := newSeededRand(seed)
+	var words []string
+	for i := 0; i < n; i++ {
+		if word, ok := source.Word(rng, minLength, maxLength); ok {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+func TestTrainMarkov(t *testing.T) {
+	model := TrainMarkov([]string{"Abc"}, 2)
+	for _, word := range pseudoWords(&PseudoWords{Model: model}, 1, 10, 0, 0) {
+		if word != "abc" {
+			t.Fatalf("Expected a model of one word to only make up that word, got %q", word)
+		}
+	}
+	// With c ruled out, generation backs off to the shorter contexts
+	words := pseudoWords(&PseudoWords{Model: model, Letters: "ab", MinLength: 4, MaxLength: 4}, 1, 10, 0, 0)
+	if len(words) != 10 || strings.Trim(strings.Join(words, ""), "ab") != "" {
+		t.Errorf("Expected 4 letter words of a and b, got %v", words)
+	}
+}
+
+func TestPseudoWordsDeterministic(t *testing.T) {
+	source := &PseudoWords{Model: bundledModel()}
+	first := pseudoWords(source, 42, 50, 0, 0)
+	if len(first) != 50 {
+		t.Fatalf("Expected 50 words, got %d", len(first))
+	}
+	if again := pseudoWords(source, 42, 50, 0, 0); !slices.Equal(first, again) {
+		t.Errorf("Expected the same words for the same seed, got %v and %v", first, again)
+	}
+	if other := pseudoWords(source, 43, 50, 0, 0); slices.Equal(first, other) {
+		t.Error("Expected other words for another seed")
+	}
+}
+
+func TestPseudoWordConstraints(t *testing.T) {
+	model := bundledModel()
+	tests := []struct {
+		name                 string
+		source               *PseudoWords
+		minLength, maxLength int
+		check                func(word string) bool
+	}{
+		{"letters", &PseudoWords{Model: model, Letters: "asdfghjkl"}, 0, 0,
+			func(w string) bool { return strings.Trim(w, "asdfghjkl") == "" }},
+		{"source lengths", &PseudoWords{Model: model, MinLength: 5, MaxLength: 6}, 0, 0,
+			func(w string) bool { return len(w) >= 5 && len(w) <= 6 }},
+		{"requested lengths", &PseudoWords{Model: model}, 3, 4,
+			func(w string) bool { return len(w) >= 3 && len(w) <= 4 }},
+		{"both lengths", &PseudoWords{Model: model, MinLength: 4, MaxLength: 8}, 2, 5,
+			func(w string) bool { return len(w) >= 4 && len(w) <= 5 }},
+		{"default length", &PseudoWords{Model: model}, 0, 0,
+			func(w string) bool { return len(w) >= 1 && len(w) <= maxPseudoWordLength }},
+		{"bigrams", &PseudoWords{Model: model, Bigrams: []string{"th", "qu"}}, 0, 0,
+			func(w string) bool { return strings.Contains(w, "th") || strings.Contains(w, "qu") }},
+		{"everything", &PseudoWords{Model: model, Letters: "etaoinshr", Bigrams: []string{"st"}}, 4, 7,
+			func(w string) bool {
+				return strings.Trim(w, "etaoinshr") == "" && strings.Contains(w, "st") && len(w) >= 4 && len(w) <= 7
+			}},
+	}
+	for _, tt := range tests {
+		words := pseudoWords(tt.source, 7, 100, tt.minLength, tt.maxLength)
+		if len(words) != 100 {
+			t.Errorf("%s: expected 100 words, got %d", tt.name, len(words))
+		}
+		for _, word := range words {
+			if !tt.check(word) {
+				t.Errorf("%s: %q breaks the constraint", tt.name, word)
+				break
+			}
+		}
+	}
+
+	impossible := []struct {
+		source               *PseudoWords
+		minLength, maxLength int
+	}{
+		{&PseudoWords{Model: model, Letters: "ab", Bigrams: []string{"zz"}}, 0, 0},
+		{&PseudoWords{Model: model, MinLength: 6}, 0, 5},
+		{&PseudoWords{Model: model, Letters: "+"}, 0, 0},
+	}
+	for _, tt := range impossible {
+		if word, ok := tt.source.Word(newSeededRand(1), tt.minLength, tt.maxLength); ok {
+			t.Errorf("Expected no word for %+v, got %q", tt.source, word)
+		}
+	}
+}
+
+func TestPseudoWordPacks(t *testing.T) {
+	pack, ok := WordPackByID("pseudo:common")
+	if !ok || pack.Source == nil || len(pack.Words) == 0 {
+		t.Fatalf("Expected a pseudo-word pack of the common words, got %v", pack)
+	}
+	if _, ok := WordPackByID("pseudo:klingon"); ok {
+		t.Error("Expected no pseudo-words of a pack that doesn't exist")
+	}
+
+	wm := NewWordManager()
+	wm.SetPack(pack)
+	wm.SetLengths(4, 6)
+	wm.Seed(3)
+	first := []string{wm.GetRandomWord(), wm.GetRandomWord(), wm.GetLongWord(8)}
+	wm.Seed(3)
+	if again := []string{wm.GetRandomWord(), wm.GetRandomWord(), wm.GetLongWord(8)}; !slices.Equal(first, again) {
+		t.Errorf("Expected the same words for the same seed, got %v and %v", first, again)
+	}
+	if len(first[0]) < 4 || len(first[0]) > 6 || len(first[2]) < 8 {
+		t.Errorf("Expected the word lengths to be kept, got %v", first)
+	}
+
+	// A source that can't make up a word falls back to the pack's words
+	wm.SetPack(&WordPack{ID: "x", Words: []string{"fallback"}, Source: &PseudoWords{Model: TrainMarkov(nil, 2)}})
+	if word := wm.GetRandomWord(); word != "fallback" {
+		t.Errorf("Expected the fallback word, got %q", word)
+	}
+}
+
+func TestPseudoWordRunReplays(t *testing.T) {
+	game := newPlayingGame(t)
+	game.State = StateMenu
+	clock := NewManualClock(time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC))
+	game.Clock = clock
+	game.ApplySettings(RunSettings{Mode: "zen", Pack: "pseudo:programming", Difficulty: DifficultyEasy, Seed: 7})
+	game.ProcessInput(' ')
+	for i := 0; i < 3; i++ {
+		for _, ch := range game.Platforms[game.Player.Platform].Word {
+			clock.Advance(200 * time.Millisecond)
+			for f := 0; f < 12; f++ {
+				game.Render()
+			}
+			game.ProcessInput(ch)
+		}
+	}
+	for f := 0; f < 60; f++ {
+		clock.Advance(time.Second / 60)
+		game.Render()
+	}
+	game.ProcessInput(KeyEscape)
+	clock.Advance(time.Second)
+	game.ProcessInput(KeyEnter)
+
+	v, err := VerifyReplay(game.LastReplay)
+	if err != nil || !v.OK() || v.Replayed.WordsTyped != 3 {
+		t.Errorf("Expected the pseudo-word run to replay, got %+v, %v", v, err)
+	}
+}
+
+func TestMenuDoesNotRebuildPacks(t *testing.T) {
+	game := newMenuGame(t)
+	game.ApplySettings(RunSettings{Mode: "zen", Pack: "classic", Difficulty: DifficultyEasy, Seed: 7})
+	plain := testing.AllocsPerRun(10, func() { game.Render() })
+
+	game.ApplySettings(RunSettings{Mode: "zen", Pack: "pseudo:programming", Difficulty: DifficultyEasy, Seed: 7})
+	if !strings.Contains(game.Render(), "(pseudo-words)") {
+		t.Error("Expected the menu to show the pseudo-word pack")
+	}
+	// Training a model allocates far more than drawing a frame
+	if built := testing.AllocsPerRun(10, func() { game.Render() }); built > plain*2 {
+		t.Errorf("Expected the menu not to rebuild the pack every frame, %.0f allocations against %.0f", built, plain)
+	}
+}
@@ -0,0 +1,158 @@
+package core
+
+import "unicode"
+
+// Keys without a character, as sent by the clients. Arrows use runes of the
+// Unicode private use area so they can't clash with typed text.
+const (
+	KeyEnter  = '\r'
+	KeyEscape = 27
+)
+
+const (
+	KeyUp rune = 0xE000 + iota
+	KeyDown
+	KeyLeft
+	KeyRight
+)
+
+// MenuItem is an entry of a Menu. Items with a Value are settings that Left,
+// Right and Enter cycle through, the others are actions run by Enter.
+type MenuItem struct {
+	Label    string
+	Shortcut rune           // key that runs the item without selecting it, 0 for none
+	Value    func() string  // current value of a setting, nil for actions
+	Run      func(step int) // runs the action, or moves the setting by step
+	Hidden   func() bool    // hides the item while it returns true, nil to always show it
+}
+
+// visible reports whether the item is shown
+func (item MenuItem) visible() bool {
+	return item.Hidden == nil || !item.Hidden()
+}
+
+// Text returns the label with the setting's value or the action's shortcut
+func (item MenuItem) Text() string {
+	return item.TextIn(nil)
+}
+
+// TextIn returns Text with the label and the value translated into l
+func (item MenuItem) TextIn(l *Locale) string {
+	label := l.Text(item.Label)
+	if item.Value != nil {
+		return label + ": < " + l.Text(item.Value()) + " >"
+	}
+	if item.Shortcut != 0 {
+		return label + " (" + keyName(item.Shortcut) + ")"
+	}
+	return label
+}
+
+// Menu is a list of items navigated with Up and Down, confirmed with Enter
+// and left with ESC
+type Menu struct {
+	Items    []MenuItem
+	Selected int    // index into Items
+	Back     func() // run by ESC
+}
+
+// Visible returns the indexes of the items that are shown
+func (m *Menu) Visible() []int {
+	var visible []int
+	for i, item := range m.Items {
+		if item.visible() {
+			visible = append(visible, i)
+		}
+	}
+	return visible
+}
+
+// Current returns the index of the selected item. A hidden selection moves
+// on to the next shown item.
+func (m *Menu) Current() int {
+	visible := m.Visible()
+	if len(visible) == 0 {
+		return -1
+	}
+	for _, i := range visible {
+		if i >= m.Selected {
+			m.Selected = i
+			return i
+		}
+	}
+	m.Selected = visible[0]
+	return m.Selected
+}
+
+// Move selects the shown item step places away, wrapping around the ends
+func (m *Menu) Move(step int) {
+	visible := m.Visible()
+	current := m.Current()
+	for pos, i := range visible {
+		if i == current {
+			m.Selected = visible[cycle(len(visible), pos, step)]
+			return
+		}
+	}
+}
+
+// Window returns the shown items that fit in rows, scrolled to keep the
+// selected one in view
+func (m *Menu) Window(rows int) []int {
+	visible := m.Visible()
+	if len(visible) <= rows {
+		return visible
+	}
+	current := m.Current()
+	first := 0
+	for pos, i := range visible {
+		if i == current {
+			first = min(max(pos-rows/2, 0), len(visible)-rows)
+		}
+	}
+	return visible[first : first+rows]
+}
+
+// ProcessInput handles the navigation keys and the shortcuts of the shown
+// items, and reports whether key was one of them
+func (m *Menu) ProcessInput(key rune) bool {
+	for _, i := range m.Visible() {
+		if item := m.Items[i]; item.Shortcut != 0 && unicode.ToLower(key) == item.Shortcut {
+			item.Run(1)
+			return true
+		}
+	}
+	switch key {
+	case KeyUp:
+		m.Move(-1)
+	case KeyDown:
+		m.Move(1)
+	case KeyEnter, '\n':
+		if current := m.Current(); current >= 0 {
+			m.Items[current].Run(1)
+		}
+	case KeyLeft, KeyRight:
+		current := m.Current()
+		if current < 0 || m.Items[current].Value == nil {
+			return false
+		}
+		step := 1
+		if key == KeyLeft {
+			step = -1
+		}
+		m.Items[current].Run(step)
+	case KeyEscape:
+		if m.Back == nil {
+			return false
+		}
+		m.Back()
+	default:
+		return false
+	}
+	return true
+}
+
+// cycle moves index i of n choices by step, wrapping around the ends
+func cycle(n, i, step int) int {
+	return ((i+step)%n + n) % n
+}
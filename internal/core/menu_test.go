@@ -0,0 +1,122 @@
+package core
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMenuNavigation(t *testing.T) {
+	var ran []string
+	hidden := true
+	value := 0
+	menu := &Menu{
+		Items: []MenuItem{
+			{Label: "Hidden", Run: func(int) { ran = append(ran, "hidden") }, Hidden: func() bool { return hidden }},
+			{Label: "Play", Shortcut: ' ', Run: func(int) { ran = append(ran, "play") }},
+			{Label: "Level", Value: func() string { return itoa(value) }, Run: func(step int) { value += step }},
+			{Label: "Quit", Shortcut: 'q', Run: func(int) { ran = append(ran, "quit") }},
+		},
+		Back: func() { ran = append(ran, "back") },
+	}
+
+	if menu.Current() != 1 {
+		t.Fatalf("Expected a hidden selection to move on to the next item, got %d", menu.Current())
+	}
+	menu.ProcessInput(KeyUp)
+	if menu.Current() != 3 {
+		t.Errorf("Expected Up to wrap around to the last item, got %d", menu.Current())
+	}
+	menu.ProcessInput(KeyDown)
+	menu.ProcessInput(KeyDown)
+	menu.ProcessInput(KeyRight)
+	menu.ProcessInput(KeyEnter)
+	menu.ProcessInput(KeyLeft)
+	menu.ProcessInput(KeyLeft)
+	menu.ProcessInput(KeyLeft)
+	if value != -1 {
+		t.Errorf("Expected Right and Enter to raise the setting and Left to lower it, got %d", value)
+	}
+	if menu.Items[2].Text() != "Level: < -1 >" || menu.Items[1].Text() != "Play (SPACE)" {
+		t.Errorf("Unexpected item texts %q and %q", menu.Items[2].Text(), menu.Items[1].Text())
+	}
+
+	menu.ProcessInput(KeyUp)
+	if menu.ProcessInput(KeyRight) {
+		t.Error("Expected Right to be ignored on an action")
+	}
+	menu.ProcessInput(KeyEnter)
+	menu.ProcessInput('Q')
+	menu.ProcessInput(KeyEscape)
+	if got := strings.Join(ran, ","); got != "play,quit,back" {
+		t.Errorf("Expected Enter, the shortcut and ESC to run play, quit and back, got %s", got)
+	}
+	if menu.ProcessInput('x') {
+		t.Error("Expected other keys to be left to the caller")
+	}
+
+	hidden = false
+	menu.Selected = 3
+	if window := menu.Window(2); len(window) != 2 || window[1] != 3 {
+		t.Errorf("Expected the window to scroll to the selection, got %v", window)
+	}
+}
+
+func TestMainMenuEnter(t *testing.T) {
+	game := newTestGame(t)
+	game.Start(80, 24)
+	game.ProcessInput(KeyEnter)
+	if game.State != StatePlaying {
+		t.Fatalf("Expected Enter to start a run, got state %v", game.State)
+	}
+
+	game.State = StateMenu
+	for game.mainMenu.Items[game.mainMenu.Current()].Label != "Settings" {
+		game.ProcessInput(KeyDown)
+	}
+	game.ProcessInput(KeyEnter)
+	if game.State != StateSettings || !strings.Contains(game.Render(), "SETTINGS") {
+		t.Fatalf("Expected Enter to open the settings, got state %v", game.State)
+	}
+	game.ProcessInput(KeyEscape)
+	if game.State != StateMenu || game.ShouldExit {
+		t.Errorf("Expected ESC to go back to the menu, got state %v", game.State)
+	}
+	game.ProcessInput(KeyEscape)
+	if !game.ShouldExit {
+		t.Error("Expected ESC in the main menu to quit")
+	}
+}
+
+func TestSettingsPersistToConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), configFileName)
+	config, _ := LoadConfig(path)
+	config.LogLevel = "off"
+	game, err := NewGameFromConfig(config)
+	if err != nil {
+		t.Fatalf("NewGameFromConfig() error: %v", err)
+	}
+	game.Start(80, 24)
+	game.ProcessInput('o')
+
+	game.ProcessInput(KeyRight) // Difficulty
+	game.ProcessInput(KeyDown)
+	game.ProcessInput(KeyLeft) // Mode, wraps to the last one
+	for i := 0; i < 3; i++ {
+		game.ProcessInput(KeyDown)
+	}
+	game.ProcessInput(KeyEnter) // Theme
+
+	if game.WordManager.Difficulty != DifficultyNormal || game.Theme.ID != "mono" {
+		t.Errorf("Expected the changes to apply at once, got difficulty %d and theme %s",
+			game.WordManager.Difficulty, game.Theme.ID)
+	}
+	loaded, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error: %v", err)
+	}
+	last := gameModes[len(gameModes)-1].ID()
+	if loaded.Difficulty != "normal" || loaded.Mode != last || loaded.Theme != "mono" {
+		t.Errorf("Expected the settings in the config file, got %+v", loaded)
+	}
+}
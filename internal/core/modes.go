@@ -0,0 +1,185 @@
+package core
+
+import (
+	"fmt"
+	"time"
+)
+
+// GameMode controls the rules of a run: how words are scored, when the run
+// ends and how finished runs are ranked against each other.
+type GameMode interface {
+	ID() string                   // stable identifier, used as the high score key
+	Name() string                 // display name shown in menus, in English
+	Description(l *Locale) string // one line summary shown in the menu
+	Scrolls() bool                // whether platforms scroll down over time
+	WordScore(g *Game, platform *Platform) ScoreBreakdown
+	OnMistake(g *Game)                             // called after every wrong key
+	IsOver(g *Game) (bool, string)                 // checked every frame, returns the end reason in English
+	Qualifies(g *Game) bool                        // whether a finished run may enter the high scores
+	Better(a, b HighScore) bool                    // reports whether a ranks above b
+	FormatEntry(l *Locale, entry HighScore) string // formats an entry by the measure it ranks on
+	Status(g *Game) string                         // mode specific HUD text, may be empty
+}
+
+// survivalMode is the classic endless mode: the run ends when the player falls.
+type survivalMode struct{}
+
+func (survivalMode) ID() string    { return "survival" }
+func (survivalMode) Name() string  { return "Survival" }
+func (survivalMode) Scrolls() bool { return true }
+
+func (survivalMode) Description(l *Locale) string {
+	return l.Text("Endless climb, the speed keeps ramping")
+}
+
+func (survivalMode) WordScore(g *Game, platform *Platform) ScoreBreakdown {
+	return standardWordScore(g, platform)
+}
+
+func (survivalMode) OnMistake(g *Game)             {}
+func (survivalMode) IsOver(g *Game) (bool, string) { return false, "" }
+func (survivalMode) Qualifies(g *Game) bool        { return g.Score > 0 }
+func (survivalMode) Better(a, b HighScore) bool    { return a.Score > b.Score }
+func (survivalMode) Status(g *Game) string         { return "" }
+
+func (survivalMode) FormatEntry(l *Locale, entry HighScore) string {
+	return l.Text("%d pts  (%.0f WPM)", entry.Score, entry.WPM)
+}
+
+// sprintMode scores as many points as possible before the timer runs out.
+type sprintMode struct {
+	survivalMode
+	duration time.Duration
+}
+
+func (m sprintMode) ID() string { return fmt.Sprintf("sprint%d", int(m.duration.Seconds())) }
+func (m sprintMode) Name() string {
+	return fmt.Sprintf("Sprint %ds", int(m.duration.Seconds()))
+}
+func (m sprintMode) Description(l *Locale) string {
+	return l.Text("Score as much as you can in %s", formatDuration(m.duration))
+}
+
+func (m sprintMode) IsOver(g *Game) (bool, string) {
+	if g.ActiveTime >= m.duration {
+		return true, "Time's up!"
+	}
+	return false, ""
+}
+
+func (m sprintMode) Status(g *Game) string {
+	remaining := m.duration - g.ActiveTime
+	if remaining < 0 {
+		remaining = 0
+	}
+	return g.tr("Left: %s", formatDuration(remaining+time.Second-1))
+}
+
+// zenMode has no scrolling and no score, only typing statistics.
+type zenMode struct {
+	survivalMode
+}
+
+func (zenMode) ID() string    { return "zen" }
+func (zenMode) Name() string  { return "Zen" }
+func (zenMode) Scrolls() bool { return false }
+
+func (zenMode) Description(l *Locale) string {
+	return l.Text("No scrolling, no pressure, just typing stats")
+}
+
+func (zenMode) WordScore(g *Game, platform *Platform) ScoreBreakdown {
+	return ScoreBreakdown{}
+}
+func (zenMode) Qualifies(g *Game) bool     { return g.WordsTyped > 0 }
+func (zenMode) Better(a, b HighScore) bool { return a.WPM > b.WPM }
+
+func (zenMode) FormatEntry(l *Locale, entry HighScore) string {
+	return l.Text("%.1f WPM  (%.0f%%)", entry.WPM, entry.Accuracy)
+}
+
+// suddenDeathMode is survival where a single wrong key ends the run.
+type suddenDeathMode struct {
+	survivalMode
+}
+
+func (suddenDeathMode) ID() string   { return "suddendeath" }
+func (suddenDeathMode) Name() string { return "Sudden Death" }
+
+func (suddenDeathMode) Description(l *Locale) string {
+	return l.Text("One wrong key and it's over")
+}
+
+func (suddenDeathMode) OnMistake(g *Game) {
+	g.endRun("Wrong key!")
+}
+
+// marathonMode is a fixed number of words played for the best time.
+type marathonMode struct {
+	survivalMode
+	words int
+}
+
+func (m marathonMode) ID() string   { return fmt.Sprintf("marathon%d", m.words) }
+func (m marathonMode) Name() string { return fmt.Sprintf("Marathon %d", m.words) }
+func (m marathonMode) Description(l *Locale) string {
+	return l.Text("Type %d words as fast as you can", m.words)
+}
+
+func (m marathonMode) IsOver(g *Game) (bool, string) {
+	if g.WordsTyped >= m.words {
+		return true, "Finished!"
+	}
+	return false, ""
+}
+
+func (m marathonMode) Qualifies(g *Game) bool { return g.WordsTyped >= m.words }
+
+func (m marathonMode) Better(a, b HighScore) bool { return a.Duration < b.Duration }
+
+func (m marathonMode) FormatEntry(l *Locale, entry HighScore) string {
+	return l.Text("%s  (%.0f WPM)", formatDuration(entry.Duration), entry.WPM)
+}
+
+func (m marathonMode) Status(g *Game) string {
+	return g.tr("Goal: %d/%d", g.WordsTyped, m.words)
+}
+
+// gameModes lists the built-in modes in menu order; the first one is the default.
+var gameModes = []GameMode{
+	survivalMode{},
+	sprintMode{duration: 60 * time.Second},
+	sprintMode{duration: 120 * time.Second},
+	zenMode{},
+	suddenDeathMode{},
+	marathonMode{words: 50},
+}
+
+// Modes returns the built-in game modes in menu order
+func Modes() []GameMode {
+	return gameModes
+}
+
+// ModeByID looks up a built-in mode by its identifier. The lesson mode isn't
+// in the menu but is found too.
+func ModeByID(id string) (GameMode, bool) {
+	if id == (lessonMode{}).ID() {
+		return lessonMode{}, true
+	}
+	for _, mode := range gameModes {
+		if mode.ID() == id {
+			return mode, true
+		}
+	}
+	return nil, false
+}
+
+// nextMode returns the mode that follows current in menu order
+func nextMode(current GameMode) GameMode {
+	for i, mode := range gameModes {
+		if mode.ID() == current.ID() {
+			return gameModes[(i+1)%len(gameModes)]
+		}
+	}
+	return gameModes[0]
+}
@@ -0,0 +1,146 @@
+package core
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newModeGame(t *testing.T, id string) *Game {
+	t.Helper()
+	mode, ok := ModeByID(id)
+	if !ok {
+		t.Fatalf("ModeByID(%q) not found", id)
+	}
+	return newPlayingGame(t, func(g *Game) {
+		g.Mode = mode
+		g.HighScores, _ = LoadHighScores(filepath.Join(t.TempDir(), "highscores.json"))
+	})
+}
+
+func TestModeCycling(t *testing.T) {
+	game := newTestGame(t)
+	game.Start(80, 24)
+
+	seen := make(map[string]bool)
+	for range Modes() {
+		seen[game.Mode.ID()] = true
+		game.ProcessInput('m')
+	}
+	if len(seen) != len(Modes()) {
+		t.Errorf("Expected to cycle through %d modes, saw %d", len(Modes()), len(seen))
+	}
+	if game.Mode.ID() != Modes()[0].ID() {
+		t.Errorf("Expected cycling to wrap around to %s, got %s", Modes()[0].ID(), game.Mode.ID())
+	}
+}
+
+func TestSprintEndsOnTimer(t *testing.T) {
+	game := newModeGame(t, "sprint60")
+
+	game.ActiveTime = 59 * time.Second
+	game.Render()
+	if game.State != StatePlaying {
+		t.Fatalf("Expected sprint to still be running, got state %v", game.State)
+	}
+
+	game.ActiveTime = 60 * time.Second
+	game.Render()
+	if game.State != StateGameOver {
+		t.Errorf("Expected sprint to end after 60s, got state %v", game.State)
+	}
+}
+
+func TestSuddenDeathEndsOnMistake(t *testing.T) {
+	game := newModeGame(t, "suddendeath")
+
+	word := game.Platforms[game.Player.Platform].Word
+	wrong := 'z'
+	if word[0] == 'z' {
+		wrong = 'y'
+	}
+	game.ProcessInput(wrong)
+
+	if game.State != StateGameOver {
+		t.Errorf("Expected wrong key to end sudden death run, got state %v", game.State)
+	}
+	if game.Mistakes != 1 {
+		t.Errorf("Expected 1 mistake, got %d", game.Mistakes)
+	}
+}
+
+func TestZenDoesNotScroll(t *testing.T) {
+	game := newModeGame(t, "zen")
+
+	startY := game.Platforms[game.Player.Platform].Y
+	for i := 0; i < 600; i++ {
+		game.Render()
+	}
+	if game.State != StatePlaying {
+		t.Fatalf("Expected zen run to keep going, got state %v", game.State)
+	}
+	if y := game.Platforms[game.Player.Platform].Y; y > game.Height/2 || y < startY {
+		t.Errorf("Expected zen platform to settle at or above the middle, got Y=%d", y)
+	}
+}
+
+func TestMarathonQualifiesOnlyWhenFinished(t *testing.T) {
+	game := newModeGame(t, "marathon50")
+
+	game.WordsTyped = 10
+	game.endRun("You fell!")
+	if game.HighScoreRank != 0 {
+		t.Errorf("Expected unfinished marathon not to be ranked, got rank %d", game.HighScoreRank)
+	}
+
+	game.State = StatePlaying
+	game.reset()
+	game.WordsTyped = 50
+	game.Render()
+	if game.State != StateGameOver {
+		t.Fatalf("Expected marathon to end after 50 words, got state %v", game.State)
+	}
+	if game.HighScoreRank != 1 {
+		t.Errorf("Expected finished marathon to rank first, got rank %d", game.HighScoreRank)
+	}
+}
+
+func TestHighScoreTableRanking(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "highscores.json")
+	table, err := LoadHighScores(path)
+	if err != nil {
+		t.Fatalf("LoadHighScores() error: %v", err)
+	}
+
+	marathon, _ := ModeByID("marathon50")
+	survival, _ := ModeByID("survival")
+
+	table.Add(marathon, HighScore{Duration: 90 * time.Second})
+	if rank := table.Add(marathon, HighScore{Duration: 60 * time.Second}); rank != 1 {
+		t.Errorf("Expected faster marathon to rank 1, got %d", rank)
+	}
+	if rank := table.Add(survival, HighScore{Score: 100}); rank != 1 {
+		t.Errorf("Expected first survival score to rank 1, got %d", rank)
+	}
+
+	for i := 0; i < maxHighScores; i++ {
+		table.Add(survival, HighScore{Score: 1000 + i})
+	}
+	if rank := table.Add(survival, HighScore{Score: 1}); rank != 0 {
+		t.Errorf("Expected low score to miss a full table, got rank %d", rank)
+	}
+	if n := len(table.Top(survival)); n != maxHighScores {
+		t.Errorf("Expected table to be capped at %d entries, got %d", maxHighScores, n)
+	}
+
+	if err := table.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	loaded, err := LoadHighScores(path)
+	if err != nil {
+		t.Fatalf("LoadHighScores() error: %v", err)
+	}
+	if best, ok := loaded.Best(marathon); !ok || best.Duration != 60*time.Second {
+		t.Errorf("Expected best marathon of 60s after reload, got %v", best.Duration)
+	}
+}
@@ -0,0 +1,111 @@
+package core
+
+import (
+	"ascii-type/assets"
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WordPack is a named list of words the WordManager draws from
+type WordPack struct {
+	ID       string // stable identifier, used in settings and challenge codes
+	Name     string // display name shown in menus
+	Language string // ISO 639-1 code of the words' language
+	Words    []string
+	Source   WordSource // makes up the words instead, Words being the fallback; nil to draw from Words
+}
+
+// classicWords is the built-in list the game has always shipped with
+var classicWords = []string{
+	"the", "and", "for", "are", "but", "not", "you", "all", "can", "her", "was", "one",
+	"our", "had", "day", "get", "use", "man", "new", "now", "way", "may", "say", "each",
+	"which", "their", "time", "will", "about", "would", "there", "could", "other", "after",
+	"first", "never", "these", "think", "where", "being", "every", "great", "might", "shall",
+	"still", "those", "while", "write", "place", "right", "where", "sound", "again", "below",
+	"between", "important", "children", "example", "sentence", "following", "without", "another",
+	"different", "thought", "through", "before", "picture", "country", "together", "followed",
+	"programming", "computer", "keyboard", "function", "variable", "algorithm", "structure",
+	"interface", "development", "framework", "library", "package", "compile", "execute",
+}
+
+// bundledPacks maps the pack IDs to the word list files in the assets package
+var bundledPacks = []struct {
+	id, name, language, file string
+}{
+	{"common", "Common Words", "en", "words.txt"},
+	{"programming", "Programming", "en", "programming.txt"},
+	{"de", "Deutsch", "de", "de.txt"},
+	{"es", "Español", "es", "es.txt"},
+	{"fr", "Français", "fr", "fr.txt"},
+}
+
+// wordPacks holds every available pack in menu order; the first one is the default
+var wordPacks = loadBundledPacks()
+
+func loadBundledPacks() []*WordPack {
+	packs := []*WordPack{{ID: "classic", Name: "Classic", Language: "en", Words: classicWords}}
+	for _, bundled := range bundledPacks {
+		file, err := assets.Files.Open(bundled.file)
+		if err != nil {
+			panic(fmt.Sprintf("bundled word pack %s: %v", bundled.file, err))
+		}
+		words, err := ParseWordList(file)
+		file.Close()
+		if err != nil {
+			panic(fmt.Sprintf("bundled word pack %s: %v", bundled.file, err))
+		}
+		packs = append(packs, &WordPack{ID: bundled.id, Name: bundled.name, Language: bundled.language, Words: words})
+	}
+	return packs
+}
+
+// ParseWordList reads one word per line, skipping blank lines and # comments
+func ParseWordList(r io.Reader) ([]string, error) {
+	var words []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, line)
+	}
+	return words, scanner.Err()
+}
+
+// WordPacks returns the available word packs in menu order
+func WordPacks() []*WordPack {
+	return wordPacks
+}
+
+// WordPackByID looks up a word pack by its identifier, including the packs
+// of keyboard drills, lessons and pseudo-words
+func WordPackByID(id string) (*WordPack, bool) {
+	if strings.HasPrefix(id, drillPrefix) {
+		return drillPackByID(id)
+	}
+	if strings.HasPrefix(id, lessonPrefix) {
+		return lessonPackByID(id)
+	}
+	if strings.HasPrefix(id, pseudoPrefix) {
+		return pseudoPackByID(id)
+	}
+	for _, pack := range wordPacks {
+		if pack.ID == id {
+			return pack, true
+		}
+	}
+	return nil, false
+}
+
+// nextWordPack returns the pack that follows the one with the given ID
+func nextWordPack(id string) *WordPack {
+	for i, pack := range wordPacks {
+		if pack.ID == id {
+			return wordPacks[(i+1)%len(wordPacks)]
+		}
+	}
+	return wordPacks[0]
+}
@@ -0,0 +1,150 @@
+package core
+
+import "time"
+
+const (
+	movingStep    = 250 * time.Millisecond // time per column moved by horizontal movers
+	crumbleDelay  = 3 * time.Second        // time a crumbling platform holds after landing
+	iceWindow     = 8 * time.Second        // time an ice platform lasts once on screen
+	narrowWidth   = 6                      // width of narrow platforms
+	narrowMinWord = 8                      // minimum word length on narrow platforms
+	warningWindow = time.Second            // countdown left when a platform starts to flicker
+	specialChance = 3                      // roughly one in specialChance platforms has a special type
+)
+
+// PlatformType selects the behaviour of a platform
+type PlatformType int
+
+const (
+	PlatformStatic    PlatformType = iota
+	PlatformMoving                 // slides left and right
+	PlatformCrumbling              // collapses a while after the player lands
+	PlatformNarrow                 // short platform carrying a long word
+	PlatformIce                    // melts a short while after it scrolls into view
+)
+
+// platformKind describes how a platform type looks and behaves
+type platformKind struct {
+	glyph  rune
+	create func(g *Game, p *Platform)                   // adjusts a freshly generated platform, may be nil
+	update func(g *Game, p *Platform, dt time.Duration) // per-frame hook, may be nil
+	land   func(g *Game, p *Platform)                   // called when the player lands on it, may be nil
+}
+
+// platformKinds maps every platform type to its behaviour
+var platformKinds = map[PlatformType]platformKind{
+	PlatformStatic:    {glyph: '='},
+	PlatformMoving:    {glyph: '-', create: createMoving, update: updateMoving},
+	PlatformCrumbling: {glyph: ':', update: updateCrumbling, land: landCrumbling},
+	PlatformNarrow:    {glyph: '=', create: createNarrow},
+	PlatformIce:       {glyph: '_', update: updateIce},
+}
+
+// specialTypes lists the types that can be rolled for generated platforms
+var specialTypes = []PlatformType{PlatformMoving, PlatformCrumbling, PlatformNarrow, PlatformIce}
+
+// Glyph returns the character used to draw the platform line
+func (p *Platform) Glyph() rune {
+	if p.Triggered && p.Timer < warningWindow && (p.Timer/(100*time.Millisecond))%2 == 0 {
+		return '.' // Flicker just before collapsing or melting
+	}
+	return platformKinds[p.Type].glyph
+}
+
+// Update runs the per-frame hook of the platform's type
+func (p *Platform) Update(g *Game, dt time.Duration) {
+	if kind := platformKinds[p.Type]; kind.update != nil && !p.Collapsed {
+		kind.update(g, p, dt)
+	}
+}
+
+// Land runs the landing hook of the platform's type
+func (p *Platform) Land(g *Game) {
+	if kind := platformKinds[p.Type]; kind.land != nil {
+		kind.land(g, p)
+	}
+}
+
+// Standable reports whether the player can stand on or jump to the platform
+func (p *Platform) Standable() bool {
+	return !p.Collapsed
+}
+
+// randomPlatformType picks the type of a newly generated platform
+func (g *Game) randomPlatformType() PlatformType {
+	if g.rng.Intn(specialChance) != 0 {
+		return PlatformStatic
+	}
+	return specialTypes[g.rng.Intn(len(specialTypes))]
+}
+
+// updatePlatforms runs every platform's per-frame hook
+func (g *Game) updatePlatforms(dt time.Duration) {
+	for i := range g.Platforms {
+		g.Platforms[i].Update(g, dt)
+	}
+}
+
+func createMoving(g *Game, p *Platform) {
+	p.Direction = 1
+	if g.rng.Intn(2) == 0 {
+		p.Direction = -1
+	}
+}
+
+// updateMoving slides the platform one column per movingStep, bouncing off the screen edges
+func updateMoving(g *Game, p *Platform, dt time.Duration) {
+	p.Timer += dt
+	for p.Timer >= movingStep {
+		p.Timer -= movingStep
+		if p.X+p.Direction < 0 || p.X+p.Width+p.Direction > g.Width {
+			p.Direction = -p.Direction
+		}
+		p.X += p.Direction
+	}
+}
+
+func landCrumbling(g *Game, p *Platform) {
+	if !p.Triggered {
+		p.Triggered = true
+		p.Timer = crumbleDelay
+	}
+}
+
+// updateCrumbling counts down once the player has landed and then collapses
+func updateCrumbling(g *Game, p *Platform, dt time.Duration) {
+	if !p.Triggered {
+		return
+	}
+	p.Timer -= dt
+	if p.Timer <= 0 {
+		p.Collapsed = true
+	}
+}
+
+// createNarrow shrinks the platform and swaps its word for a long one
+func createNarrow(g *Game, p *Platform) {
+	p.X += (p.Width - narrowWidth) / 2
+	p.Width = narrowWidth
+	p.Word = g.WordManager.GetLongWord(narrowMinWord)
+}
+
+// updateIce starts melting when the platform scrolls into view and
+// melts away unless its word has been completed
+func updateIce(g *Game, p *Platform, dt time.Duration) {
+	if p.Complete {
+		p.Triggered = false
+		return
+	}
+	if !p.Triggered {
+		if p.Y >= 0 {
+			p.Triggered = true
+			p.Timer = iceWindow
+		}
+		return
+	}
+	p.Timer -= dt
+	if p.Timer <= 0 {
+		p.Collapsed = true
+	}
+}
@@ -0,0 +1,162 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+// newPlatformGame starts a run whose platforms are all static
+func newPlatformGame(t *testing.T) *Game {
+	t.Helper()
+	game := newPlayingGame(t)
+	for i := range game.Platforms {
+		game.Platforms[i].Type = PlatformStatic
+		game.Platforms[i].PowerUp = PowerUpNone
+	}
+	return game
+}
+
+func TestStaticPlatformHasNoBehaviour(t *testing.T) {
+	game := newPlatformGame(t)
+	p := Platform{X: 10, Y: 5, Width: 10, Type: PlatformStatic}
+
+	p.Land(game)
+	p.Update(game, 10*time.Second)
+
+	if p.X != 10 || p.Triggered || p.Collapsed {
+		t.Errorf("Expected static platform to stay put, got %+v", p)
+	}
+	if p.Glyph() != '=' {
+		t.Errorf("Expected static glyph '=', got %q", p.Glyph())
+	}
+}
+
+func TestMovingPlatformBounces(t *testing.T) {
+	game := newPlatformGame(t)
+	p := Platform{X: game.Width - 12, Y: 5, Width: 10, Type: PlatformMoving, Direction: 1}
+
+	p.Update(game, movingStep)
+	if p.X != game.Width-11 {
+		t.Fatalf("Expected mover to move one column right, got X=%d", p.X)
+	}
+
+	p.Update(game, 3*movingStep)
+	if p.Direction != -1 {
+		t.Errorf("Expected mover to bounce off the right edge, direction %d", p.Direction)
+	}
+	if p.X+p.Width > game.Width {
+		t.Errorf("Expected mover to stay on screen, got X=%d width=%d", p.X, p.Width)
+	}
+}
+
+func TestMovingPlatformCarriesPlayer(t *testing.T) {
+	game := newPlatformGame(t)
+	current := &game.Platforms[game.Player.Platform]
+	current.Type = PlatformMoving
+	current.Direction = 1
+	x := game.Player.X
+
+	for i := 0; i < 60; i++ {
+		game.Render()
+	}
+	if game.Player.X <= x {
+		t.Errorf("Expected player to ride the mover right from X=%d, got X=%d", x, game.Player.X)
+	}
+}
+
+func TestCrumblingPlatformCollapsesAfterLanding(t *testing.T) {
+	game := newPlatformGame(t)
+	p := Platform{Y: 5, Width: 10, Type: PlatformCrumbling}
+
+	p.Update(game, 10*time.Second)
+	if p.Collapsed {
+		t.Fatal("Expected crumbling platform to hold until landed on")
+	}
+
+	p.Land(game)
+	p.Update(game, crumbleDelay-time.Millisecond)
+	if p.Collapsed {
+		t.Fatal("Expected crumbling platform to hold during its delay")
+	}
+	p.Update(game, time.Millisecond)
+	if !p.Collapsed {
+		t.Error("Expected crumbling platform to collapse after its delay")
+	}
+}
+
+func TestCrumblingPlatformDropsPlayer(t *testing.T) {
+	game := newPlatformGame(t)
+	current := &game.Platforms[game.Player.Platform]
+	current.Type = PlatformCrumbling
+	current.Land(game)
+	game.ScrollSpeed = 0 // Only the collapse may drop the player
+
+	for i := 0; i < int(crumbleDelay/time.Second)*60+1; i++ {
+		game.Render()
+	}
+	if game.State != StateGameOver {
+		t.Errorf("Expected collapse to end a run without lives, got state %v", game.State)
+	}
+}
+
+func TestNarrowPlatformGetsLongWord(t *testing.T) {
+	game := newPlatformGame(t)
+	p := Platform{X: 10, Y: 5, Width: 20, Word: "cat", Type: PlatformNarrow}
+
+	createNarrow(game, &p)
+
+	if p.Width != narrowWidth {
+		t.Errorf("Expected narrow width %d, got %d", narrowWidth, p.Width)
+	}
+	if len(p.Word) < narrowMinWord {
+		t.Errorf("Expected word of at least %d chars, got %q", narrowMinWord, p.Word)
+	}
+	if p.X+p.Width/2 != 10+20/2 {
+		t.Errorf("Expected narrow platform to stay centred, got X=%d", p.X)
+	}
+}
+
+func TestIcePlatformMeltsOnScreen(t *testing.T) {
+	game := newPlatformGame(t)
+	p := Platform{Y: -5, Width: 10, Type: PlatformIce}
+
+	p.Update(game, time.Minute)
+	if p.Triggered || p.Collapsed {
+		t.Fatal("Expected ice platform to hold while above the screen")
+	}
+
+	p.Y = 2
+	p.Update(game, 0)
+	p.Update(game, iceWindow)
+	if !p.Collapsed {
+		t.Error("Expected ice platform to melt after its window")
+	}
+
+	done := Platform{Y: 2, Width: 10, Type: PlatformIce, Complete: true}
+	done.Update(game, 0)
+	done.Update(game, iceWindow)
+	if done.Collapsed {
+		t.Error("Expected completed ice platform not to melt")
+	}
+}
+
+func TestJumpSkipsCollapsedPlatforms(t *testing.T) {
+	game := newPlatformGame(t)
+	current := game.Player.Platform
+
+	next := -1
+	for i, p := range game.Platforms {
+		if p.Y < game.Platforms[current].Y && (next == -1 || p.Y > game.Platforms[next].Y) {
+			next = i
+		}
+	}
+	if next == -1 {
+		t.Fatal("Expected a platform above the start")
+	}
+	game.Platforms[next].Collapsed = true
+
+	typeCurrentWord(game, false)
+	if game.Player.Platform == next {
+		t.Error("Expected player not to land on a collapsed platform")
+	}
+}
@@ -0,0 +1,158 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	powerUpChance  = 8                // roughly one in powerUpChance platforms carries a power-up
+	slowFactor     = 0.5              // scroll speed multiplier while slowed
+	slowDuration   = 10 * time.Second // how long the slow scroll lasts
+	doubleDuration = 15 * time.Second // how long double points last
+	freezeDuration = 20 * time.Second // how long the speed ramp stays frozen
+)
+
+// PowerUp is an effect granted by completing the word of a special platform
+type PowerUp int
+
+const (
+	PowerUpNone   PowerUp = iota
+	PowerUpSlow           // slows the scroll for a while
+	PowerUpSkip           // skips the next word
+	PowerUpShield         // saves the player from one fall
+	PowerUpDouble         // doubles the points of every word for a while
+	PowerUpFreeze         // stops the speed ramp for a while
+)
+
+// powerUps lists the power-ups that can appear on platforms
+var powerUps = []PowerUp{PowerUpSlow, PowerUpSkip, PowerUpShield, PowerUpDouble, PowerUpFreeze}
+
+// Glyph returns the character used to draw a platform carrying the power-up
+func (p PowerUp) Glyph() rune {
+	switch p {
+	case PowerUpSlow:
+		return '~'
+	case PowerUpSkip:
+		return '>'
+	case PowerUpShield:
+		return '*'
+	case PowerUpDouble:
+		return '$'
+	case PowerUpFreeze:
+		return '%'
+	default:
+		return '='
+	}
+}
+
+// Label returns the short name shown on the HUD
+func (p PowerUp) Label() string {
+	switch p {
+	case PowerUpSlow:
+		return "SLOW"
+	case PowerUpSkip:
+		return "SKIP"
+	case PowerUpShield:
+		return "SHIELD"
+	case PowerUpDouble:
+		return "2X"
+	case PowerUpFreeze:
+		return "FREEZE"
+	default:
+		return ""
+	}
+}
+
+// Effect is a timed power-up that is currently active
+type Effect struct {
+	PowerUp   PowerUp
+	Remaining time.Duration
+}
+
+// EffectActive reports whether a timed power-up is currently running
+func (g *Game) EffectActive(p PowerUp) bool {
+	for _, effect := range g.Effects {
+		if effect.PowerUp == p {
+			return true
+		}
+	}
+	return false
+}
+
+// randomPowerUp picks the power-up for a newly generated platform, if any
+func (g *Game) randomPowerUp() PowerUp {
+	if g.rng.Intn(powerUpChance) != 0 {
+		return PowerUpNone
+	}
+	return powerUps[g.rng.Intn(len(powerUps))]
+}
+
+// applyPowerUp grants the effect of a completed special platform
+func (g *Game) applyPowerUp(p PowerUp) {
+	if p == PowerUpNone {
+		return
+	}
+	g.Logger.Printf("applyPowerUp: %s", p.Label())
+	g.showCallout(p.Label() + "!")
+
+	switch p {
+	case PowerUpSlow:
+		g.addEffect(p, slowDuration)
+	case PowerUpDouble:
+		g.addEffect(p, doubleDuration)
+	case PowerUpFreeze:
+		g.addEffect(p, freezeDuration)
+	case PowerUpShield:
+		g.Shields++
+	case PowerUpSkip:
+		g.skipWord()
+	}
+}
+
+// addEffect starts a timed effect, refreshing its duration if already active
+func (g *Game) addEffect(p PowerUp, duration time.Duration) {
+	for i := range g.Effects {
+		if g.Effects[i].PowerUp == p {
+			g.Effects[i].Remaining = duration
+			return
+		}
+	}
+	g.Effects = append(g.Effects, Effect{PowerUp: p, Remaining: duration})
+}
+
+// tickEffects counts down the timed effects and drops the expired ones
+func (g *Game) tickEffects(dt time.Duration) {
+	active := g.Effects[:0]
+	for _, effect := range g.Effects {
+		effect.Remaining -= dt
+		if effect.Remaining > 0 {
+			active = append(active, effect)
+		}
+	}
+	g.Effects = active
+}
+
+// skipWord completes the player's current word without scoring it
+func (g *Game) skipWord() {
+	if len(g.Platforms) == 0 || g.Platforms[g.Player.Platform].Complete {
+		return
+	}
+	g.Logger.Printf("skipWord: skipping %s", g.Platforms[g.Player.Platform].Word)
+	g.Platforms[g.Player.Platform].Complete = true
+	g.jumpToNextPlatform()
+}
+
+// effectsStatus formats the active effects and their countdowns for the HUD
+func (g *Game) effectsStatus() string {
+	var parts []string
+	for _, effect := range g.Effects {
+		seconds := int((effect.Remaining + time.Second - 1) / time.Second)
+		parts = append(parts, fmt.Sprintf("%s %ds", effect.PowerUp.Label(), seconds))
+	}
+	if g.Shields > 0 {
+		parts = append(parts, fmt.Sprintf("%s x%d", PowerUpShield.Label(), g.Shields))
+	}
+	return strings.Join(parts, " | ")
+}
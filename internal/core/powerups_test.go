@@ -0,0 +1,94 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimedEffectsExpire(t *testing.T) {
+	game := newPlayingGame(t)
+
+	game.applyPowerUp(PowerUpSlow)
+	if !game.EffectActive(PowerUpSlow) {
+		t.Fatal("Expected slow effect to be active")
+	}
+
+	game.tickEffects(slowDuration - time.Second)
+	if !game.EffectActive(PowerUpSlow) {
+		t.Error("Expected slow effect to still be active before its duration")
+	}
+	game.tickEffects(time.Second)
+	if game.EffectActive(PowerUpSlow) {
+		t.Error("Expected slow effect to expire after its duration")
+	}
+}
+
+func TestSlowHalvesScroll(t *testing.T) {
+	game := newPlayingGame(t)
+	game.ScrollSpeed = 60 // one row per frame
+
+	game.applyPowerUp(PowerUpSlow)
+	y := game.Platforms[0].Y
+	game.Render()
+	game.Render()
+	if moved := game.Platforms[0].Y - y; moved != 1 {
+		t.Errorf("Expected slowed scroll to move 1 row in 2 frames, moved %d", moved)
+	}
+}
+
+func TestDoublePointsAndFreeze(t *testing.T) {
+	game := newPlayingGame(t)
+	game.applyPowerUp(PowerUpDouble)
+	game.applyPowerUp(PowerUpFreeze)
+	game.WordsTyped = game.Tuning.Speed.Interval - 1
+	speed := game.ScrollSpeed
+
+	before := game.Score
+	typeCurrentWord(game, false)
+	gained := game.Score - before
+
+	if game.Breakdown.PowerUp == 0 || gained != 2*(gained-game.Breakdown.PowerUp) {
+		t.Errorf("Expected double points, gained %d with %d from power-ups", gained, game.Breakdown.PowerUp)
+	}
+	if game.ScrollSpeed != speed {
+		t.Errorf("Expected frozen ramp to keep speed %.2f, got %.2f", speed, game.ScrollSpeed)
+	}
+}
+
+func TestShieldSavesFromFall(t *testing.T) {
+	game := newPlayingGame(t)
+	game.applyPowerUp(PowerUpShield)
+
+	dropPlayer(game)
+	game.Render()
+
+	if game.State != StatePlaying {
+		t.Fatalf("Expected shield to save the run, got state %v", game.State)
+	}
+	if game.Shields != 0 {
+		t.Errorf("Expected shield to be used up, got %d", game.Shields)
+	}
+}
+
+func TestSkipCompletesNextWord(t *testing.T) {
+	game := newPlayingGame(t)
+	for i := range game.Platforms {
+		game.Platforms[i].PowerUp = PowerUpNone
+	}
+	game.Platforms[game.Player.Platform].PowerUp = PowerUpSkip
+
+	typeCurrentWord(game, false)
+
+	if game.WordsTyped != 1 {
+		t.Errorf("Expected skipped word not to count as typed, got %d words", game.WordsTyped)
+	}
+	skipped := 0
+	for _, platform := range game.Platforms {
+		if platform.Complete {
+			skipped++
+		}
+	}
+	if skipped != 2 {
+		t.Errorf("Expected the typed and the skipped platform to be complete, got %d", skipped)
+	}
+}
@@ -0,0 +1,147 @@
+package core
+
+import "fmt"
+
+// Difficulty levels, the level of a preset is its position in presets plus one
+const (
+	DifficultyEasy = iota + 1
+	DifficultyNormal
+	DifficultyHard
+	DifficultyInsane
+	DifficultyCustom // tuned by the config file
+)
+
+// Tuning is everything a difficulty preset sets: how fast the run scrolls,
+// which words it picks and how far apart its platforms are
+type Tuning struct {
+	Speed   SpeedCurve `json:"speed"`
+	MinWord int        `json:"min_word"` // shortest word, in characters
+	MaxWord int        `json:"max_word"` // longest word, 0 for no limit
+	Spacing int        `json:"spacing"`  // rows between platforms
+}
+
+// Validate checks that the tuning is playable
+func (t Tuning) Validate() error {
+	if err := t.Speed.Validate(); err != nil {
+		return err
+	}
+	switch {
+	case t.MinWord < 1:
+		return fmt.Errorf("%w: shortest word must be at least 1 character, got %d", ErrInvalidConfig, t.MinWord)
+	case t.MaxWord != 0 && t.MaxWord < t.MinWord:
+		return fmt.Errorf("%w: longest word must be 0 or at least %d, got %d", ErrInvalidConfig, t.MinWord, t.MaxWord)
+	case t.Spacing < 5 || t.Spacing > 20:
+		return fmt.Errorf("%w: platform spacing must be between 5 and 20, got %d", ErrInvalidConfig, t.Spacing)
+	}
+	return nil
+}
+
+// Preset is a named difficulty
+type Preset struct {
+	ID     string
+	Name   string
+	Tuning Tuning
+}
+
+// normalTuning is the tuning of Normal, the one the game was tuned with
+var normalTuning = Tuning{
+	Speed:   SpeedCurve{Start: initialScrollSpeed, Factor: speedIncreaseFactor, Interval: speedIncreaseThreshold, Max: 20},
+	MinWord: 4, MaxWord: 8, Spacing: platformSpacing,
+}
+
+// presets holds every difficulty in menu order. Custom starts out as Normal
+// and is tuned by the config file.
+var presets = []*Preset{
+	{ID: "easy", Name: "Easy", Tuning: Tuning{
+		Speed:   SpeedCurve{Start: 4, Factor: 1.04, Interval: 6, Max: 12},
+		MinWord: 3, MaxWord: 5, Spacing: 11,
+	}},
+	{ID: "normal", Name: "Normal", Tuning: normalTuning},
+	{ID: "hard", Name: "Hard", Tuning: Tuning{
+		Speed:   SpeedCurve{Start: 6, Factor: 1.06, Interval: 4, Max: 30},
+		MinWord: 6, Spacing: 9,
+	}},
+	{ID: "insane", Name: "Insane", Tuning: Tuning{
+		Speed:   SpeedCurve{Start: 8, Factor: 1.08, Interval: 3, Max: 45},
+		MinWord: 7, Spacing: 8,
+	}},
+	{ID: "custom", Name: "Custom", Tuning: normalTuning},
+}
+
+// Presets returns every difficulty preset in menu order
+func Presets() []*Preset {
+	return presets
+}
+
+// PresetByID looks up a difficulty preset by its ID
+func PresetByID(id string) (*Preset, bool) {
+	for _, preset := range presets {
+		if preset.ID == id {
+			return preset, true
+		}
+	}
+	return nil, false
+}
+
+// PresetByLevel looks up a difficulty preset by its level
+func PresetByLevel(level int) (*Preset, bool) {
+	if level < 1 || level > len(presets) {
+		return nil, false
+	}
+	return presets[level-1], true
+}
+
+// presetLevel returns the difficulty level of a preset
+func presetLevel(preset *Preset) int {
+	for i, p := range presets {
+		if p == preset {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// presetName returns the display name of a difficulty level
+func presetName(level int) string {
+	if preset, ok := PresetByLevel(level); ok {
+		return preset.Name
+	}
+	return "Any"
+}
+
+// presetTuning returns the tuning of a difficulty level, Custom takes the
+// custom tuning of the config
+func (g *Game) presetTuning(level int) Tuning {
+	if level == DifficultyCustom && g.Config != nil {
+		return g.Config.Custom
+	}
+	if preset, ok := PresetByLevel(level); ok {
+		return preset.Tuning
+	}
+	return g.Tuning
+}
+
+// SetDifficulty switches to the preset of a difficulty level
+func (g *Game) SetDifficulty(level int) {
+	if _, ok := PresetByLevel(level); !ok {
+		return
+	}
+	g.WordManager.SetDifficulty(level)
+	g.SetTuning(g.presetTuning(level))
+}
+
+// SetTuning sets the speed curve, word lengths and platform spacing of the
+// following runs
+func (g *Game) SetTuning(t Tuning) {
+	g.Tuning = t
+	g.WordManager.SetLengths(t.MinWord, t.MaxWord)
+}
+
+// Preset returns the difficulty preset of the following runs
+func (g *Game) Preset() *Preset {
+	preset, ok := PresetByLevel(g.WordManager.Difficulty)
+	if !ok {
+		return presets[DifficultyNormal-1]
+	}
+	return preset
+}
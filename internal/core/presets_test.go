@@ -0,0 +1,101 @@
+package core
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPresetsAreValid(t *testing.T) {
+	for i, preset := range Presets() {
+		if err := preset.Tuning.Validate(); err != nil {
+			t.Errorf("%s: %v", preset.Name, err)
+		}
+		if found, ok := PresetByLevel(i + 1); !ok || found != preset || presetLevel(preset) != i+1 {
+			t.Errorf("%s: expected level %d", preset.Name, i+1)
+		}
+	}
+}
+
+func TestMenuCyclesPresets(t *testing.T) {
+	game := newTestGame(t)
+	game.Start(80, 24)
+	for _, want := range []int{DifficultyNormal, DifficultyHard, DifficultyInsane, DifficultyCustom, DifficultyEasy, DifficultyNormal, DifficultyHard} {
+		game.ProcessInput('d')
+		if game.WordManager.Difficulty != want {
+			t.Fatalf("Expected difficulty %d, got %d", want, game.WordManager.Difficulty)
+		}
+	}
+	if !strings.Contains(game.Render(), "Difficulty: Hard") {
+		t.Error("Expected the menu to name the preset")
+	}
+
+	hard := presets[DifficultyHard-1].Tuning
+	game.ProcessInput(' ')
+	if game.Tuning != hard || game.ScrollSpeed != hard.Speed.Start {
+		t.Errorf("Expected the Hard tuning, got %+v at speed %.1f", game.Tuning, game.ScrollSpeed)
+	}
+	for i := 1; i < len(game.Platforms); i++ {
+		if gap := game.Platforms[i-1].Y - game.Platforms[i].Y; gap < hard.Spacing {
+			t.Errorf("Expected platforms at least %d rows apart, got %d", hard.Spacing, gap)
+		}
+		if word := game.Platforms[i].Word; len(word) < hard.MinWord {
+			t.Errorf("Expected words of at least %d letters, got %q", hard.MinWord, word)
+		}
+	}
+}
+
+func TestCustomPresetUsesConfig(t *testing.T) {
+	config := DefaultConfig()
+	config.Difficulty = "custom"
+	config.Custom = Tuning{Speed: SpeedCurve{Start: 9, Factor: 1.1, Interval: 2}, MinWord: 2, MaxWord: 3, Spacing: 6}
+	config.LogLevel = "off"
+	game, err := NewGameFromConfig(config)
+	if err != nil {
+		t.Fatalf("NewGameFromConfig() error: %v", err)
+	}
+	if game.Tuning != config.Custom || game.WordManager.MaxLength != 3 {
+		t.Errorf("Expected the custom tuning, got %+v", game.Tuning)
+	}
+	game.SetDifficulty(DifficultyEasy)
+	game.SetDifficulty(DifficultyCustom)
+	if game.Tuning != config.Custom {
+		t.Errorf("Expected to get the custom tuning back, got %+v", game.Tuning)
+	}
+}
+
+func TestSpeedRampStopsAtMax(t *testing.T) {
+	game := newPlayingGame(t)
+	game.SetTuning(Tuning{Speed: SpeedCurve{Start: 5, Factor: 2, Interval: 1, Max: 12}, MinWord: 1, Spacing: 10})
+	game.ScrollSpeed = 5
+
+	typeCurrentWord(game, false)
+	if game.ScrollSpeed != 10 {
+		t.Errorf("Expected the first ramp to double the speed, got %.1f", game.ScrollSpeed)
+	}
+	typeCurrentWord(game, false)
+	typeCurrentWord(game, false)
+	if game.ScrollSpeed != 12 {
+		t.Errorf("Expected the speed to stop at the maximum, got %.1f", game.ScrollSpeed)
+	}
+}
+
+func TestHighScoreRecordsPreset(t *testing.T) {
+	game := newTestGame(t)
+	game.HighScores, _ = LoadHighScores(filepath.Join(t.TempDir(), "highscores.json"))
+	game.Start(80, 24)
+	game.SetDifficulty(DifficultyInsane)
+	game.ProcessInput(' ')
+	typeCurrentWord(game, false)
+	game.ProcessInput(27)
+	game.ProcessInput('\r')
+
+	best, ok := game.HighScores.Best(game.Mode)
+	if !ok || best.Preset != "insane" {
+		t.Fatalf("Expected the score to record the Insane preset, got %+v", best)
+	}
+	game.ProcessInput('m')
+	if !strings.Contains(game.Render(), "| Insane") {
+		t.Error("Expected the menu high scores to name the preset")
+	}
+}
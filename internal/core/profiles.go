@@ -0,0 +1,678 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Profile screen actions that read a line of input
+const (
+	profileActionNone   = ""
+	profileActionCreate = "create"
+	profileActionRename = "rename"
+	profileActionDelete = "delete" // waiting for Y to confirm
+	profileActionImport = "import"
+)
+
+const maxProfileInput = 64
+
+const (
+	profilesFile       = "profiles.json"
+	defaultProfileName = "Player"
+	maxProfileName     = 20
+)
+
+// ErrInvalidProfile is returned for bad profile names and profile operations
+// that aren't possible, like deleting the last profile
+var ErrInvalidProfile = errors.New("invalid profile")
+
+// ProfileSettings are the menu settings a profile starts with
+type ProfileSettings struct {
+	Mode       string `json:"mode"`
+	Pack       string `json:"pack"`
+	Difficulty int    `json:"difficulty"`
+	Lives      int    `json:"lives"`
+	Layout     string `json:"layout,omitempty"` // keyboard layout ID
+}
+
+// defaultProfileSettings returns the settings of a new profile
+func defaultProfileSettings() ProfileSettings {
+	return ProfileSettings{
+		Mode:       gameModes[0].ID(),
+		Pack:       wordPacks[0].ID,
+		Difficulty: 1,
+		Lives:      livesOptions[0],
+		Layout:     layouts[0].ID,
+	}
+}
+
+// Profile is a named player with their own settings, high scores, daily
+// results, session history, key analytics and lesson progress, stored in a
+// directory of their own
+type Profile struct {
+	Name       string
+	Settings   ProfileSettings
+	HighScores *HighScoreTable
+	Daily      *DailyHistory
+	Keys       *KeyStats
+	History    *SessionHistory
+	Lessons    *LessonProgress
+	dir        string
+}
+
+// ReplayDir returns the directory the profile's replays are saved to
+func (p *Profile) ReplayDir() string {
+	return filepath.Join(p.dir, "replays")
+}
+
+// SaveFile returns the file a paused run of the profile is saved to
+func (p *Profile) SaveFile() string {
+	return filepath.Join(p.dir, saveFile)
+}
+
+// SaveSettings writes the profile's settings
+func (p *Profile) SaveSettings() error {
+	if err := os.MkdirAll(p.dir, 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(p.Settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(p.dir, "settings.json"), data, 0644)
+}
+
+// ProfileStore is the list of profiles kept in a directory, one
+// subdirectory per profile
+type ProfileStore struct {
+	Active   string   `json:"active"`
+	Profiles []string `json:"profiles"` // names in creation order
+	dir      string
+	created  bool // the store didn't exist on disk yet
+}
+
+// LoadProfiles reads the profile list from dir. A missing list is not an
+// error and yields a store with a single default profile.
+func LoadProfiles(dir string) (*ProfileStore, error) {
+	store := &ProfileStore{dir: dir}
+	data, err := os.ReadFile(filepath.Join(dir, profilesFile))
+	if errors.Is(err, os.ErrNotExist) {
+		store.Active = defaultProfileName
+		store.Profiles = []string{defaultProfileName}
+		store.created = true
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, err
+	}
+	if len(store.Profiles) == 0 {
+		store.Profiles = []string{defaultProfileName}
+	}
+	if store.index(store.Active) < 0 {
+		store.Active = store.Profiles[0]
+	}
+	return store, nil
+}
+
+// Save writes the profile list
+func (s *ProfileStore) Save() error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	s.created = false
+	return os.WriteFile(filepath.Join(s.dir, profilesFile), data, 0644)
+}
+
+// Open loads a profile and everything stored for it
+func (s *ProfileStore) Open(name string) (*Profile, error) {
+	if s.index(name) < 0 {
+		return nil, fmt.Errorf("%w: no profile named %q", ErrInvalidProfile, name)
+	}
+	dir := s.profileDir(name)
+	profile := &Profile{Name: name, Settings: defaultProfileSettings(), dir: dir}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "settings.json"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, &profile.Settings); err != nil {
+			return nil, err
+		}
+	}
+	if profile.HighScores, err = LoadHighScores(filepath.Join(dir, highScoresFile)); err != nil {
+		return nil, err
+	}
+	if profile.Daily, err = LoadDailyHistory(filepath.Join(dir, dailyHistoryFile)); err != nil {
+		return nil, err
+	}
+	if profile.Keys, err = LoadKeyStats(filepath.Join(dir, keyStatsFile)); err != nil {
+		return nil, err
+	}
+	if profile.History, err = LoadHistory(filepath.Join(dir, historyFile)); err != nil {
+		return nil, err
+	}
+	if profile.Lessons, err = LoadLessonProgress(filepath.Join(dir, lessonsFile)); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// Select makes an existing profile the active one
+func (s *ProfileStore) Select(name string) error {
+	if s.index(name) < 0 {
+		return fmt.Errorf("%w: no profile named %q, expected one of %s",
+			ErrInvalidProfile, name, strings.Join(s.Profiles, ", "))
+	}
+	s.Active = name
+	return nil
+}
+
+// Create adds a new, empty profile
+func (s *ProfileStore) Create(name string) error {
+	name = strings.TrimSpace(name)
+	if err := s.checkNewName(name); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.profileDir(name), 0755); err != nil {
+		return err
+	}
+	s.Profiles = append(s.Profiles, name)
+	return s.Save()
+}
+
+// Rename gives a profile a new name, moving its directory along
+func (s *ProfileStore) Rename(old, name string) error {
+	name = strings.TrimSpace(name)
+	i := s.index(old)
+	if i < 0 {
+		return fmt.Errorf("%w: no profile named %q", ErrInvalidProfile, old)
+	}
+	if profileSlug(name) != profileSlug(old) {
+		if err := s.checkNewName(name); err != nil {
+			return err
+		}
+		if err := os.Rename(s.profileDir(old), s.profileDir(name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	} else if err := validProfileName(name); err != nil {
+		return err
+	}
+	s.Profiles[i] = name
+	if s.Active == old {
+		s.Active = name
+	}
+	return s.Save()
+}
+
+// Delete removes a profile and everything stored for it. The last profile
+// can't be deleted.
+func (s *ProfileStore) Delete(name string) error {
+	i := s.index(name)
+	if i < 0 {
+		return fmt.Errorf("%w: no profile named %q", ErrInvalidProfile, name)
+	}
+	if len(s.Profiles) == 1 {
+		return fmt.Errorf("%w: can't delete the last profile", ErrInvalidProfile)
+	}
+	if err := os.RemoveAll(s.profileDir(name)); err != nil {
+		return err
+	}
+	s.Profiles = append(s.Profiles[:i], s.Profiles[i+1:]...)
+	if s.Active == name {
+		s.Active = s.Profiles[0]
+	}
+	return s.Save()
+}
+
+// profileExport is the file format of an exported profile
+type profileExport struct {
+	Name       string          `json:"name"`
+	Settings   ProfileSettings `json:"settings"`
+	HighScores *HighScoreTable `json:"high_scores"`
+	Daily      *DailyHistory   `json:"daily"`
+	Keys       *KeyStats       `json:"keys"`
+	History    []Session       `json:"history"`
+	Lessons    *LessonProgress `json:"lessons,omitempty"`
+}
+
+// Export writes a profile to a single file that Import can read back.
+// Replays aren't included.
+func (s *ProfileStore) Export(name, path string) error {
+	profile, err := s.Open(name)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(profileExport{
+		Name:       profile.Name,
+		Settings:   profile.Settings,
+		HighScores: profile.HighScores,
+		Daily:      profile.Daily,
+		Keys:       profile.Keys,
+		History:    profile.History.Sessions,
+		Lessons:    profile.Lessons,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Import adds the profile exported to path. If the name is taken a number is
+// added to it. It returns the name of the imported profile.
+func (s *ProfileStore) Import(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	var exported profileExport
+	if err := json.Unmarshal(data, &exported); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidProfile, err)
+	}
+	if err := validProfileName(exported.Name); err != nil {
+		return "", err
+	}
+
+	name := exported.Name
+	for n := 2; s.slugTaken(name); n++ {
+		name = fmt.Sprintf("%s %d", exported.Name, n)
+	}
+	if err := s.Create(name); err != nil {
+		return "", err
+	}
+
+	profile := &Profile{Name: name, Settings: exported.Settings, dir: s.profileDir(name)}
+	if err := profile.SaveSettings(); err != nil {
+		return "", err
+	}
+	if exported.HighScores != nil {
+		// The replays stay behind, their paths point into the exporting profile
+		for _, scores := range exported.HighScores.Modes {
+			for i := range scores {
+				scores[i].Replay = ""
+			}
+		}
+		exported.HighScores.path = filepath.Join(profile.dir, highScoresFile)
+		if err := exported.HighScores.Save(); err != nil {
+			return "", err
+		}
+	}
+	if exported.Daily != nil {
+		exported.Daily.path = filepath.Join(profile.dir, dailyHistoryFile)
+		if err := exported.Daily.Save(); err != nil {
+			return "", err
+		}
+	}
+	if exported.Keys != nil {
+		exported.Keys.path = filepath.Join(profile.dir, keyStatsFile)
+		if err := exported.Keys.Save(); err != nil {
+			return "", err
+		}
+	}
+	if exported.Lessons != nil {
+		exported.Lessons.path = filepath.Join(profile.dir, lessonsFile)
+		if err := exported.Lessons.Save(); err != nil {
+			return "", err
+		}
+	}
+	history := &SessionHistory{path: filepath.Join(profile.dir, historyFile)}
+	for _, session := range exported.History {
+		if err := history.Append(session); err != nil {
+			return "", err
+		}
+	}
+	return name, nil
+}
+
+// ExportPath returns the default file a profile is exported to
+func ExportPath(name string) string {
+	return profileSlug(name) + ".profile.json"
+}
+
+func (s *ProfileStore) index(name string) int {
+	for i, profile := range s.Profiles {
+		if profile == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// slugTaken reports whether a profile already uses the directory of name
+func (s *ProfileStore) slugTaken(name string) bool {
+	for _, profile := range s.Profiles {
+		if profileSlug(profile) == profileSlug(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *ProfileStore) checkNewName(name string) error {
+	if err := validProfileName(name); err != nil {
+		return err
+	}
+	if s.slugTaken(name) {
+		return fmt.Errorf("%w: %q is already taken", ErrInvalidProfile, name)
+	}
+	return nil
+}
+
+func (s *ProfileStore) profileDir(name string) string {
+	return filepath.Join(s.dir, profileSlug(name))
+}
+
+// validProfileName accepts short names of letters, digits, spaces, - and _
+func validProfileName(name string) error {
+	if name == "" || len(name) > maxProfileName {
+		return fmt.Errorf("%w: names are 1 to %d characters", ErrInvalidProfile, maxProfileName)
+	}
+	for _, r := range name {
+		if !isProfileNameChar(r) {
+			return fmt.Errorf("%w: %q can't be used in a name", ErrInvalidProfile, r)
+		}
+	}
+	return nil
+}
+
+func isProfileNameChar(r rune) bool {
+	return isAlphanumeric(r) || r == ' ' || r == '-' || r == '_'
+}
+
+// profileSlug turns a profile name into its directory name
+func profileSlug(name string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), " ", "-")
+}
+
+// SetProfiles switches the game to the active profile of store. A store
+// created just now adopts the high scores and daily history the game
+// loaded from before profiles existed.
+func (g *Game) SetProfiles(store *ProfileStore) error {
+	profile, err := store.Open(store.Active)
+	if err != nil {
+		return err
+	}
+	if store.created {
+		if g.HighScores != nil && len(g.HighScores.Modes) > 0 {
+			profile.HighScores.Modes = g.HighScores.Modes
+			if err := profile.HighScores.Save(); err != nil {
+				return err
+			}
+		}
+		if g.DailyHistory != nil && len(g.DailyHistory.Results) > 0 {
+			profile.Daily.Results = g.DailyHistory.Results
+			if err := profile.Daily.Save(); err != nil {
+				return err
+			}
+		}
+		if g.SavedRun != nil {
+			if err := g.SavedRun.Save(profile.SaveFile()); err != nil {
+				return err
+			}
+			if err := os.Remove(g.SaveFile); err != nil {
+				g.Logger.Printf("SetProfiles: failed to remove the adopted save: %v", err)
+			}
+		}
+		if err := store.Save(); err != nil {
+			return err
+		}
+	}
+	g.Profiles = store
+	g.useProfile(profile)
+	return nil
+}
+
+// useProfile makes profile the active one and applies its settings
+func (g *Game) useProfile(profile *Profile) {
+	g.Profile = profile
+	g.HighScores = profile.HighScores
+	g.DailyHistory = profile.Daily
+	g.KeyStats = profile.Keys
+	g.History = profile.History
+	g.Lessons = profile.Lessons
+	g.ReplayDir = profile.ReplayDir()
+	g.ClearGhost()
+
+	// A saved run belongs to the profile that played it
+	var err error
+	g.SaveFile = profile.SaveFile()
+	if g.SavedRun, err = LoadSaveGame(g.SaveFile); err != nil {
+		g.Logger.Printf("useProfile: failed to load saved run: %v", err)
+		g.MenuMessage = g.tr("The saved run can't be continued: %s", err)
+	}
+
+	s := profile.Settings
+	if mode, ok := ModeByID(s.Mode); ok {
+		g.Mode = mode
+	}
+	if pack, ok := WordPackByID(s.Pack); ok {
+		g.WordManager.SetPack(pack)
+	}
+	if _, ok := PresetByLevel(s.Difficulty); ok {
+		g.SetDifficulty(s.Difficulty)
+	}
+	for _, lives := range livesOptions {
+		if lives == s.Lives {
+			g.StartingLives = lives
+		}
+	}
+	g.Layout = layouts[0]
+	if layout, ok := LayoutByID(s.Layout); ok {
+		g.Layout = layout
+	}
+	g.ApplyConfig() // Explicit configuration wins over the profile's last choices
+	g.Logger.Printf("useProfile: %s", profile.Name)
+}
+
+// switchProfile opens another profile and makes it the active one
+func (g *Game) switchProfile(name string) error {
+	profile, err := g.Profiles.Open(name)
+	if err != nil {
+		return err
+	}
+	g.Profiles.Active = name
+	if err := g.Profiles.Save(); err != nil {
+		return err
+	}
+	g.useProfile(profile)
+	return nil
+}
+
+// saveProfileSettings stores the menu settings in the active profile
+func (g *Game) saveProfileSettings() {
+	if g.Profile == nil {
+		return
+	}
+	g.Profile.Settings = ProfileSettings{
+		Mode:       g.Mode.ID(),
+		Pack:       g.WordManager.Pack,
+		Difficulty: g.WordManager.Difficulty,
+		Lives:      g.StartingLives,
+		Layout:     g.Layout.ID,
+	}
+	if err := g.Profile.SaveSettings(); err != nil {
+		g.Logger.Printf("saveProfileSettings: failed to save: %v", err)
+	}
+}
+
+// saveKeyStats writes the key analytics of the active profile
+func (g *Game) saveKeyStats() {
+	if g.KeyStats == nil {
+		return
+	}
+	if err := g.KeyStats.Save(); err != nil {
+		g.Logger.Printf("saveKeyStats: failed to save: %v", err)
+	}
+}
+
+// ProfileName returns the name of the active profile, empty without profiles
+func (g *Game) ProfileName() string {
+	if g.Profile == nil {
+		return ""
+	}
+	return g.Profile.Name
+}
+
+// newProfilesMenu lists the profiles, the active one selected. Enter or the
+// number of one of the first nine switches to it.
+func (g *Game) newProfilesMenu() *Menu {
+	menu := &Menu{Back: func() { g.State = StateMenu }}
+	if g.Profiles == nil {
+		return menu
+	}
+	for i, name := range g.Profiles.Profiles {
+		name := name
+		item := MenuItem{Label: name, Run: func(int) { g.selectProfile(name) }}
+		if i < 9 {
+			item.Shortcut = rune('1' + i)
+		}
+		if name == g.ProfileName() {
+			item.Label += " - " + g.tr("active")
+			menu.Selected = i
+		}
+		menu.Items = append(menu.Items, item)
+	}
+	return menu
+}
+
+// openProfiles shows the profile screen
+func (g *Game) openProfiles() {
+	g.profilesMenu = g.newProfilesMenu()
+	g.State = StateProfiles
+}
+
+// selectProfile switches to the profile picked on the profile screen
+func (g *Game) selectProfile(name string) {
+	if err := g.switchProfile(name); err != nil {
+		g.MenuMessage = g.tr("Can't switch profiles: %s", err)
+		return
+	}
+	g.profilesMenu = g.newProfilesMenu()
+	g.MenuMessage = g.tr("Playing as %s", g.Profile.Name)
+}
+
+// processProfilesInput handles the profile screen: the menu switches
+// profiles, letters start an action on the active one
+func (g *Game) processProfilesInput(key rune) {
+	g.Logger.Debugf("processProfilesInput: key=%v", key)
+	if g.profileAction != profileActionNone {
+		g.processProfileAction(key)
+		return
+	}
+	g.MenuMessage = ""
+	if g.profilesMenu.ProcessInput(key) {
+		return
+	}
+	switch {
+	case key == 'n' || key == 'N':
+		g.profileAction = profileActionCreate
+	case key == 'r' || key == 'R':
+		g.profileAction = profileActionRename
+		g.profileInput = g.Profile.Name
+	case key == 'x' || key == 'X':
+		g.profileAction = profileActionDelete
+	case key == 'e' || key == 'E':
+		path := ExportPath(g.Profile.Name)
+		if err := g.Profiles.Export(g.Profile.Name, path); err != nil {
+			g.MenuMessage = g.tr("Export failed: %s", err)
+			return
+		}
+		g.MenuMessage = g.tr("Exported to %s", path)
+	case key == 'i' || key == 'I':
+		g.profileAction = profileActionImport
+	case key == 'q' || key == 'Q':
+		g.State = StateMenu
+	}
+}
+
+// processProfileAction reads the input of a profile screen action
+func (g *Game) processProfileAction(key rune) {
+	if g.profileAction == profileActionDelete {
+		if key == 'y' || key == 'Y' {
+			g.deleteProfile()
+			g.profilesMenu = g.newProfilesMenu()
+		}
+		g.profileAction = profileActionNone
+		return
+	}
+
+	switch key {
+	case 27: // ESC - cancel
+		g.profileAction = profileActionNone
+		g.profileInput = ""
+	case '\r', '\n': // Enter - apply
+		if err := g.applyProfileAction(); err != nil {
+			g.MenuMessage = err.Error()
+			return
+		}
+		g.profileAction = profileActionNone
+		g.profileInput = ""
+		g.profilesMenu = g.newProfilesMenu()
+	case 8, 127: // Backspace
+		if len(g.profileInput) > 0 {
+			g.profileInput = g.profileInput[:len(g.profileInput)-1]
+		}
+	default:
+		if key >= ' ' && key < 127 && len(g.profileInput) < maxProfileInput {
+			g.profileInput += string(key)
+		}
+	}
+}
+
+// applyProfileAction runs the create, rename or import typed on the screen
+func (g *Game) applyProfileAction() error {
+	switch g.profileAction {
+	case profileActionCreate:
+		if err := g.Profiles.Create(g.profileInput); err != nil {
+			return err
+		}
+		if err := g.switchProfile(strings.TrimSpace(g.profileInput)); err != nil {
+			return err
+		}
+		g.MenuMessage = g.tr("Created %s", g.Profile.Name)
+	case profileActionRename:
+		if err := g.Profiles.Rename(g.Profile.Name, g.profileInput); err != nil {
+			return err
+		}
+		// Reopen it so its files follow the new directory
+		if err := g.switchProfile(g.Profiles.Active); err != nil {
+			return err
+		}
+		g.MenuMessage = g.tr("Renamed to %s", g.Profile.Name)
+	case profileActionImport:
+		name, err := g.Profiles.Import(strings.TrimSpace(g.profileInput))
+		if err != nil {
+			return err
+		}
+		g.MenuMessage = g.tr("Imported %s", name)
+	}
+	return nil
+}
+
+// deleteProfile removes the active profile and switches to the first one left
+func (g *Game) deleteProfile() {
+	name := g.Profile.Name
+	if err := g.Profiles.Delete(name); err != nil {
+		g.MenuMessage = err.Error()
+		return
+	}
+	if err := g.switchProfile(g.Profiles.Active); err != nil {
+		g.MenuMessage = g.tr("Can't switch profiles: %s", err)
+		return
+	}
+	g.MenuMessage = g.tr("Deleted %s", name)
+}
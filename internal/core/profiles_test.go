@@ -0,0 +1,227 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// profileGame returns a game in the menu using a fresh profile store in a
+// temporary directory
+func profileGame(t *testing.T) *Game {
+	t.Helper()
+	game := newMenuGame(t, func(g *Game) {
+		g.DailyHistory = nil
+		g.SaveFile = ""
+	})
+
+	store, err := LoadProfiles(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadProfiles() error: %v", err)
+	}
+	if err := game.SetProfiles(store); err != nil {
+		t.Fatalf("SetProfiles() error: %v", err)
+	}
+	return game
+}
+
+func typeText(game *Game, text string) {
+	for _, ch := range text {
+		game.ProcessInput(ch)
+	}
+}
+
+func TestProfileStoreLifecycle(t *testing.T) {
+	dir := t.TempDir()
+	store, err := LoadProfiles(dir)
+	if err != nil {
+		t.Fatalf("LoadProfiles() error: %v", err)
+	}
+	if store.Active != defaultProfileName || len(store.Profiles) != 1 {
+		t.Fatalf("Expected a single default profile, got %+v", store)
+	}
+
+	if err := store.Create("Alice"); err != nil {
+		t.Fatalf("Create() error: %v", err)
+	}
+	for _, name := range []string{"alice", "", "a/b", "a name that is far too long"} {
+		if err := store.Create(name); !errors.Is(err, ErrInvalidProfile) {
+			t.Errorf("Create(%q): expected ErrInvalidProfile, got %v", name, err)
+		}
+	}
+
+	if err := store.Rename("Alice", "Bob"); err != nil {
+		t.Fatalf("Rename() error: %v", err)
+	}
+	if err := store.Delete(defaultProfileName); err != nil {
+		t.Fatalf("Delete() error: %v", err)
+	}
+	if err := store.Delete("Bob"); !errors.Is(err, ErrInvalidProfile) {
+		t.Errorf("Expected the last profile to be kept, got %v", err)
+	}
+
+	reloaded, err := LoadProfiles(dir)
+	if err != nil {
+		t.Fatalf("LoadProfiles() error: %v", err)
+	}
+	if reloaded.Active != "Bob" || len(reloaded.Profiles) != 1 || reloaded.Profiles[0] != "Bob" {
+		t.Errorf("Expected only Bob after reloading, got %+v", reloaded)
+	}
+}
+
+func TestProfileExportImport(t *testing.T) {
+	store, err := LoadProfiles(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadProfiles() error: %v", err)
+	}
+	profile, err := store.Open(defaultProfileName)
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	profile.Settings.Mode = "zen"
+	profile.HighScores.Add(gameModes[0], HighScore{Score: 420, Date: time.Now().UTC(), Replay: filepath.Join(profile.ReplayDir(), "run.json")})
+	profile.Keys.Record('a', false, 0)
+	if err := profile.SaveSettings(); err != nil {
+		t.Fatalf("SaveSettings() error: %v", err)
+	}
+	if err := profile.HighScores.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	if err := profile.Keys.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), ExportPath(profile.Name))
+	if err := store.Export(profile.Name, path); err != nil {
+		t.Fatalf("Export() error: %v", err)
+	}
+	name, err := store.Import(path)
+	if err != nil {
+		t.Fatalf("Import() error: %v", err)
+	}
+	if name != defaultProfileName+" 2" {
+		t.Errorf("Expected the imported profile to get a free name, got %q", name)
+	}
+
+	imported, err := store.Open(name)
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	if imported.Settings.Mode != "zen" {
+		t.Errorf("Expected imported settings, got %+v", imported.Settings)
+	}
+	if best, ok := imported.HighScores.Best(gameModes[0]); !ok || best.Score != 420 || best.Replay != "" {
+		t.Errorf("Expected the imported high score without its replay, got %+v", best)
+	}
+	if imported.Keys.Keys["a"].Misses != 1 {
+		t.Errorf("Expected imported key analytics, got %+v", imported.Keys.Keys)
+	}
+}
+
+func TestSwitchingProfilesKeepsSettingsApart(t *testing.T) {
+	game := profileGame(t)
+	game.ProcessInput('m') // Change the default profile's mode
+	mode := game.Mode.ID()
+
+	game.ProcessInput('u')
+	game.ProcessInput('n')
+	typeText(game, "Bob")
+	game.ProcessInput('\r')
+	if game.ProfileName() != "Bob" {
+		t.Fatalf("Expected to play as the new profile, got %q (%s)", game.ProfileName(), game.MenuMessage)
+	}
+	if game.Mode.ID() == mode {
+		t.Errorf("Expected the new profile to start with default settings, got %s", mode)
+	}
+	bobScores := game.HighScores
+
+	game.ProcessInput('1')
+	if game.ProfileName() != defaultProfileName || game.Mode.ID() != mode {
+		t.Errorf("Expected the first profile and its mode %s back, got %q with %s",
+			mode, game.ProfileName(), game.Mode.ID())
+	}
+	if game.HighScores == bobScores {
+		t.Error("Expected every profile to have its own high scores")
+	}
+
+	game.ProcessInput(27)
+	if game.State != StateMenu {
+		t.Errorf("Expected ESC to return to the menu, got state %v", game.State)
+	}
+}
+
+func TestProfilesPastTheNinthCanBeSelected(t *testing.T) {
+	game := profileGame(t)
+	game.ProcessInput('u')
+	for i := 1; i <= 11; i++ {
+		game.ProcessInput('n')
+		typeText(game, fmt.Sprintf("P%02d", i))
+		game.ProcessInput('\r')
+	}
+	if game.ProfileName() != "P11" || !strings.Contains(game.Render(), "P11 - active") {
+		t.Fatalf("Expected to play as the last new profile, got %q (%s)", game.ProfileName(), game.MenuMessage)
+	}
+
+	game.ProcessInput(KeyUp)
+	game.ProcessInput(KeyEnter)
+	if game.ProfileName() != "P10" {
+		t.Errorf("Expected UP and ENTER to switch to the tenth profile, got %q", game.ProfileName())
+	}
+	game.ProcessInput('1')
+	if game.ProfileName() != defaultProfileName {
+		t.Errorf("Expected 1 to switch to the first profile, got %q", game.ProfileName())
+	}
+}
+
+func TestSavedRunBelongsToItsProfile(t *testing.T) {
+	game := profileGame(t)
+	game.ProcessInput(' ')
+	game.ProcessInput(27)
+	game.ProcessInput('s')
+	if game.SavedRun == nil {
+		t.Fatalf("Expected the run to be saved (%s)", game.MenuMessage)
+	}
+	if _, err := os.Stat(game.Profile.SaveFile()); err != nil {
+		t.Errorf("Expected the save in the profile's directory, got %v", err)
+	}
+
+	game.ProcessInput('u')
+	game.ProcessInput('n')
+	typeText(game, "Bob")
+	game.ProcessInput('\r')
+	if game.SavedRun != nil {
+		t.Error("Expected another profile not to see the saved run")
+	}
+
+	game.ProcessInput('1')
+	if game.SavedRun == nil {
+		t.Error("Expected the saved run back with its profile")
+	}
+}
+
+func TestKeyStatsRecorded(t *testing.T) {
+	game := profileGame(t)
+	game.ProcessInput(' ')
+
+	word := game.Platforms[game.Player.Platform].Word
+	typeCurrentWord(game, true)
+
+	first := game.KeyStats.Keys[string(word[0])]
+	if first.Misses != 1 || first.Hits < 1 {
+		t.Errorf("Expected a miss and a hit on %q, got %+v", word[0], first)
+	}
+	hits := 0
+	for _, stat := range game.KeyStats.Keys {
+		hits += stat.Hits
+	}
+	if hits != len(word) {
+		t.Errorf("Expected %d hits, got %d", len(word), hits)
+	}
+	if weakest := game.KeyStats.Weakest(1); len(weakest) != 1 || weakest[0] != string(word[0]) {
+		t.Errorf("Expected %q to be the weakest key, got %v", word[0], weakest)
+	}
+}
@@ -0,0 +1,70 @@
+package core
+
+// progressDays is how many days the progress screen charts
+const progressDays = 7
+
+// progressCounts are the choices for how many recent sessions are charted
+var progressCounts = []int{10, 25, 50}
+
+// progressMetric is a stat charted on the progress screen
+type progressMetric struct {
+	Name   string
+	Format string // fmt verb for a value
+	Value  func(Stats) float64
+	Day    func(DaySummary) float64
+}
+
+var progressMetrics = []progressMetric{
+	{"WPM", "%.1f", func(s Stats) float64 { return s.WPM }, func(d DaySummary) float64 { return d.WPM }},
+	{"Accuracy", "%.1f%%", func(s Stats) float64 { return s.Accuracy }, func(d DaySummary) float64 { return d.Accuracy }},
+	{"Score", "%.0f", func(s Stats) float64 { return float64(s.Score) }, func(d DaySummary) float64 { return d.Score }},
+}
+
+// values returns the metric of every session
+func (m progressMetric) values(sessions []Session) []float64 {
+	values := make([]float64, len(sessions))
+	for i, session := range sessions {
+		values[i] = m.Value(session.Stats)
+	}
+	return values
+}
+
+// progressSessions returns the sessions shown on the progress screen
+func (g *Game) progressSessions() []Session {
+	if g.History == nil {
+		return nil
+	}
+	return g.History.Filter(g.progressMode)
+}
+
+// processProgressInput handles the progress screen: M picks the mode, N the
+// number of sessions, C the metric of the daily bars and E exports them
+func (g *Game) processProgressInput(key rune) {
+	g.Logger.Debugf("processProgressInput: key=%v", key)
+	g.MenuMessage = ""
+	switch key {
+	case 'm', 'M':
+		g.progressMode = nextProgressMode(g.progressMode)
+	case 'n', 'N':
+		g.progressCount = (g.progressCount + 1) % len(progressCounts)
+	case 'c', 'C':
+		g.progressMetric = (g.progressMetric + 1) % len(progressMetrics)
+	case 'e', 'E':
+		g.exportProgress()
+	case 27, 'q', 'Q':
+		g.State = StateMenu
+	}
+}
+
+// nextProgressMode cycles from all modes through every single mode
+func nextProgressMode(current string) string {
+	if current == "" {
+		return gameModes[0].ID()
+	}
+	for i, mode := range gameModes {
+		if mode.ID() == current && i+1 < len(gameModes) {
+			return gameModes[i+1].ID()
+		}
+	}
+	return ""
+}
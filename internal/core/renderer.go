@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"strings"
 	"time"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
 )
 
 // Colors for terminal output
@@ -17,6 +20,7 @@ const (
 	ColorCyan   = "\033[36m"
 	ColorWhite  = "\033[37m"
 	ColorBold   = "\033[1m"
+	ColorDim    = "\033[2m"
 )
 
 // Renderer handles ASCII art rendering for the game
@@ -41,18 +45,32 @@ func (r *Renderer) UpdateDimensions(width, height int) {
 
 // RenderGame renders the complete game state
 func (r *Renderer) RenderGame(g *Game) string {
+	var frame string
 	switch g.State {
 	case StateMenu:
-		return r.renderMenu(g)
+		frame = r.renderMenu(g)
 	case StatePlaying:
-		return r.renderGameplay(g)
+		frame = r.renderGameplay(g)
 	case StatePaused:
-		return r.renderPaused(g)
+		frame = r.renderPaused(g)
 	case StateGameOver:
-		return r.renderGameOver(g)
+		frame = r.renderGameOver(g)
+	case StateDailyHistory:
+		frame = r.renderDailyHistory(g)
+	case StateProfiles:
+		frame = r.renderProfiles(g)
+	case StateProgress:
+		frame = r.renderProgress(g)
+	case StateSettings:
+		frame = r.renderSettings(g)
+	case StateKeyboard:
+		frame = r.renderKeyboard(g)
+	case StateLessons:
+		frame = r.renderLessons(g)
 	default:
-		return "Unknown game state"
+		return g.tr("Unknown game state")
 	}
+	return g.Theme.apply(frame)
 }
 
 // renderMenu renders the main menu
@@ -67,19 +85,244 @@ func (r *Renderer) renderMenu(g *Game) string {
 	centerX := r.width / 2
 
 	// Title
-	title := "ASCII TYPING PLATFORMER"
-	titleX := centerX - len(title)/2
-	r.writeAtPosition(&sb, titleX, centerY-3, ColorBold+ColorCyan+title+ColorReset)
+	title := g.tr("ASCII TYPING PLATFORMER")
+	titleX := centerX - textWidth(title)/2
+	r.writeAtPosition(&sb, titleX, centerY-8, ColorBold+ColorCyan+title+ColorReset)
+	if name := g.ProfileName(); name != "" {
+		profileLine := g.tr("Player: %s", name)
+		r.writeAtPosition(&sb, centerX-textWidth(profileLine)/2, centerY-7, ColorGreen+profileLine+ColorReset)
+	}
 
-	// Menu options
-	options := []string{
-		"Press SPACE to Start",
-		"Press Q to Quit",
+	// Selected mode
+	modeLine := g.tr("Mode: < %s >", g.tr(g.Mode.Name()))
+	r.writeAtPosition(&sb, centerX-textWidth(modeLine)/2, centerY-6, ColorYellow+modeLine+ColorReset)
+	description := g.Mode.Description(g.Locale)
+	r.writeAtPosition(&sb, centerX-textWidth(description)/2, centerY-5, ColorWhite+description+ColorReset)
+
+	// Run settings
+	settingsLine := g.tr("Pack: %s | Difficulty: %s | Lives: %s",
+		g.tr(g.WordManager.PackName), g.tr(presetName(g.WordManager.Difficulty)), g.tr(livesName(g.StartingLives)))
+	r.writeAtPosition(&sb, centerX-textWidth(settingsLine)/2, centerY-4, ColorWhite+settingsLine+ColorReset)
+	if code, err := EncodeChallenge(g.RunSettings(), g.StartingLives); g.FixedSeed && err == nil {
+		challengeLine := g.tr("Challenge: %s", code)
+		r.writeAtPosition(&sb, centerX-textWidth(challengeLine)/2, centerY-3, ColorPurple+challengeLine+ColorReset)
+	}
+	if g.Ghost != nil {
+		ghostLine := g.tr("Racing ghost: %s (%d words, %d points)",
+			g.GhostName, g.Ghost.Stats.WordsTyped, g.Ghost.Stats.Score)
+		r.writeAtPosition(&sb, centerX-textWidth(ghostLine)/2, centerY-2, ColorDim+ghostLine+ColorReset)
 	}
 
-	for i, option := range options {
-		optionX := centerX - len(option)/2
-		r.writeAtPosition(&sb, optionX, centerY+i, ColorWhite+option+ColorReset)
+	// Menu options, the saved run's details replace the ghost line
+	if g.SavedRun != nil && g.Ghost == nil {
+		mode, _ := ModeByID(g.SavedRun.Settings.Mode)
+		savedLine := g.tr("Saved run: %s, %d points", g.tr(mode.Name()), g.SavedRun.Score)
+		r.writeAtPosition(&sb, centerX-textWidth(savedLine)/2, centerY-2, ColorDim+savedLine+ColorReset)
+	}
+	shortcuts := g.tr("M mode | P pack | D difficulty | L lives")
+	r.writeAtPosition(&sb, centerX-textWidth(shortcuts)/2, centerY-1, ColorWhite+shortcuts+ColorReset)
+	r.drawMenu(&sb, g.Locale, g.mainMenu, centerX, centerY, 5)
+
+	// Challenge code input and feedback
+	if g.codeEntry {
+		prompt := g.tr("Code: %s_ (ENTER load, ESC cancel)", g.codeInput)
+		r.writeAtPosition(&sb, centerX-textWidth(prompt)/2, centerY+5, ColorBold+ColorYellow+prompt+ColorReset)
+	}
+	if g.MenuMessage != "" {
+		r.writeAtPosition(&sb, centerX-textWidth(g.MenuMessage)/2, centerY+6, ColorCyan+g.MenuMessage+ColorReset)
+	}
+
+	// High scores for the selected mode
+	if g.HighScores != nil {
+		top := g.HighScores.Top(g.Mode)
+		for i := 0; i < len(top) && i < 3; i++ {
+			line := fmt.Sprintf("%d. %s", i+1, g.Mode.FormatEntry(g.Locale, top[i]))
+			if preset, ok := PresetByID(top[i].Preset); ok {
+				line += " | " + g.tr(preset.Name)
+			}
+			r.writeAtPosition(&sb, centerX-textWidth(line)/2, centerY+7+i, ColorGreen+line+ColorReset)
+		}
+	}
+	hint := g.tr("UP/DOWN select | ENTER confirm | ESC quit")
+	r.writeAtPosition(&sb, centerX-textWidth(hint)/2, centerY+10, ColorDim+hint+ColorReset)
+
+	return sb.String()
+}
+
+// drawMenu draws the items of m that fit in rows, centered from line top and
+// translated into l. The selected item is highlighted, arrows show that more
+// items scroll in.
+func (r *Renderer) drawMenu(sb *strings.Builder, l *Locale, m *Menu, centerX, top, rows int) {
+	visible, window := m.Visible(), m.Window(rows)
+	current := m.Current()
+	for i, index := range window {
+		text := m.Items[index].TextIn(l)
+		color := ColorWhite
+		if index == current {
+			text = "> " + text + "  " // Keeps the label where it was
+			color = ColorBold + ColorYellow
+		}
+		r.writeAtPosition(sb, centerX-textWidth(text)/2, top+i, color+text+ColorReset)
+	}
+	if len(window) < len(visible) {
+		if window[0] != visible[0] {
+			r.writeAtPosition(sb, centerX+20, top, ColorDim+"^"+ColorReset)
+		}
+		if window[len(window)-1] != visible[len(visible)-1] {
+			r.writeAtPosition(sb, centerX+20, top+len(window)-1, ColorDim+"v"+ColorReset)
+		}
+	}
+}
+
+// renderLessons renders the curriculum with the profile's progress and the
+// keys and pass thresholds of the selected lesson
+func (r *Renderer) renderLessons(g *Game) string {
+	var sb strings.Builder
+
+	// Clear screen
+	sb.WriteString("\033[2J\033[H")
+
+	centerY := r.height / 2
+	centerX := r.width / 2
+
+	title := g.tr("LESSONS")
+	r.writeAtPosition(&sb, centerX-textWidth(title)/2, centerY-8, ColorBold+ColorCyan+title+ColorReset)
+	layoutLine := g.tr("Layout: %s", g.Layout.Name)
+	if name := g.ProfileName(); name != "" {
+		layoutLine = g.tr("Player: %s", name) + " | " + layoutLine
+	}
+	r.writeAtPosition(&sb, centerX-textWidth(layoutLine)/2, centerY-7, ColorGreen+layoutLine+ColorReset)
+
+	r.drawMenu(&sb, g.Locale, g.lessonsMenu, centerX, centerY-5, len(lessons)+1)
+
+	if current := g.lessonsMenu.Current(); current >= 0 && current < len(lessons) {
+		lesson := lessons[current]
+		_, introduced := lesson.Characters(g.Layout)
+		keysLine := g.tr("New keys: %s", string(introduced))
+		goalLine := g.tr("Pass: %s over %d words", lesson.Goal(g.Locale), lessonWords)
+		r.writeAtPosition(&sb, centerX-textWidth(keysLine)/2, centerY+2, ColorCyan+keysLine+ColorReset)
+		r.writeAtPosition(&sb, centerX-textWidth(goalLine)/2, centerY+3, ColorWhite+goalLine+ColorReset)
+	}
+	if g.MenuMessage != "" {
+		r.writeAtPosition(&sb, centerX-textWidth(g.MenuMessage)/2, centerY+5, ColorYellow+g.MenuMessage+ColorReset)
+	}
+	hint := g.tr("UP/DOWN select | ENTER or 1-%d start | ESC back", len(lessons))
+	r.writeAtPosition(&sb, centerX-textWidth(hint)/2, centerY+7, ColorWhite+hint+ColorReset)
+
+	return sb.String()
+}
+
+// keyboardIndent is how far each row of the keyboard heatmap is shifted to
+// the right, like the rows of a real keyboard
+var keyboardIndent = [4]int{0, 3, 4, 5}
+
+// renderKeyboard renders the keyboard screen: a heatmap of the accuracy of
+// every key on the active layout, hand statistics and the drills
+func (r *Renderer) renderKeyboard(g *Game) string {
+	var sb strings.Builder
+
+	// Clear screen
+	sb.WriteString("\033[2J\033[H")
+
+	centerY := r.height / 2
+	centerX := r.width / 2
+
+	title := g.tr("KEYBOARD")
+	r.writeAtPosition(&sb, centerX-textWidth(title)/2, centerY-10, ColorBold+ColorCyan+title+ColorReset)
+	layoutLine := g.tr("Layout: %s", g.Layout.Name)
+	r.writeAtPosition(&sb, centerX-textWidth(layoutLine)/2, centerY-9, ColorYellow+layoutLine+ColorReset)
+
+	// Every key colored by its accuracy
+	keyStats := g.KeyStats
+	if keyStats == nil {
+		keyStats = &KeyStats{}
+	}
+	left := centerX - 15
+	for row, keys := range g.Layout.Rows {
+		var line strings.Builder
+		for _, key := range keys {
+			stat := keyStats.Keys[string(key)]
+			color := ColorDim
+			switch {
+			case stat.attempts() == 0:
+			case stat.Accuracy() >= 97:
+				color = ColorGreen
+			case stat.Accuracy() >= 90:
+				color = ColorYellow
+			default:
+				color = ColorRed
+			}
+			line.WriteString(color + string(key) + ColorReset + " ")
+		}
+		r.writeAtPosition(&sb, left+keyboardIndent[row], centerY-7+row, line.String())
+	}
+	legendParts := []string{"97%+", "90%+", g.tr("below 90%"), g.tr("not typed yet")}
+	legend := ColorGreen + legendParts[0] + ColorReset + " | " + ColorYellow + legendParts[1] + ColorReset + " | " +
+		ColorRed + legendParts[2] + ColorReset + " | " + ColorDim + legendParts[3] + ColorReset
+	r.writeAtPosition(&sb, centerX-textWidth(strings.Join(legendParts, " | "))/2, centerY-2, legend)
+
+	// Hands, fingers and key pairs
+	hands := keyStats.HandStats(g.Layout)
+	handsLine := g.tr("No keys typed yet")
+	if finger, ok := hands.WeakestFinger(); ok {
+		handsLine = g.tr("Left hand: %.1f%% | Right hand: %.1f%% | Weakest: %s %.1f%%",
+			hands.Hands[HandLeft].Accuracy(), hands.Hands[HandRight].Accuracy(),
+			g.tr(finger.Name()), hands.Fingers[finger-1].Accuracy())
+	}
+	r.writeAtPosition(&sb, centerX-textWidth(handsLine)/2, centerY, ColorWhite+handsLine+ColorReset)
+	pairsLine := g.tr("No key pairs typed yet")
+	if total := hands.Pairs(); total > 0 {
+		share := func(k KeyStat) string {
+			return fmt.Sprintf("%d%% %dms", k.attempts()*100/total, k.AverageLatency().Milliseconds())
+		}
+		pairsLine = g.tr("Pairs: alternating %s | same hand %s | same finger %s",
+			share(hands.Alternating), share(hands.SameHand), share(hands.SameFinger))
+	}
+	r.writeAtPosition(&sb, centerX-textWidth(pairsLine)/2, centerY+1, ColorWhite+pairsLine+ColorReset)
+
+	// Drills, two per line
+	for i := 0; i < len(drills); i += 2 {
+		line := fmt.Sprintf("%d %s", i+1, padRight(g.tr(drills[i].Name), 22))
+		if i+1 < len(drills) {
+			line += fmt.Sprintf("%d %s", i+2, padRight(g.tr(drills[i+1].Name), 22))
+		}
+		r.writeAtPosition(&sb, centerX-24, centerY+3+i/2, ColorGreen+line+ColorReset)
+	}
+	if g.MenuMessage != "" {
+		r.writeAtPosition(&sb, centerX-textWidth(g.MenuMessage)/2, centerY+7, ColorCyan+g.MenuMessage+ColorReset)
+	}
+	hint := g.tr("1-%d start a drill | L change layout | ESC back", len(drills))
+	r.writeAtPosition(&sb, centerX-textWidth(hint)/2, centerY+9, ColorDim+hint+ColorReset)
+
+	return sb.String()
+}
+
+// renderSettings renders the settings screen
+func (r *Renderer) renderSettings(g *Game) string {
+	var sb strings.Builder
+
+	// Clear screen
+	sb.WriteString("\033[2J\033[H")
+
+	centerY := r.height / 2
+	centerX := r.width / 2
+
+	title := g.tr("SETTINGS")
+	r.writeAtPosition(&sb, centerX-textWidth(title)/2, centerY-8, ColorBold+ColorCyan+title+ColorReset)
+	if name := g.ProfileName(); name != "" {
+		profileLine := g.tr("Player: %s", name)
+		r.writeAtPosition(&sb, centerX-textWidth(profileLine)/2, centerY-7, ColorGreen+profileLine+ColorReset)
+	}
+
+	r.drawMenu(&sb, g.Locale, g.settingsMenu, centerX, centerY-5, 10)
+
+	hint := g.tr("UP/DOWN select | LEFT/RIGHT or ENTER change | ESC back")
+	if g.bindingCapture != "" {
+		hint = g.tr("Press the new key for %s", g.tr(g.bindingCapture.Name()))
+	}
+	r.writeAtPosition(&sb, centerX-textWidth(hint)/2, centerY+6, ColorWhite+hint+ColorReset)
+	if g.MenuMessage != "" {
+		r.writeAtPosition(&sb, centerX-textWidth(g.MenuMessage)/2, centerY+8, ColorCyan+g.MenuMessage+ColorReset)
 	}
 
 	return sb.String()
@@ -111,8 +354,10 @@ func (r *Renderer) renderGameplay(g *Game) string {
 		r.drawPlatform(grid, platform)
 	}
 
-	// Draw player
-	r.drawPlayer(grid, g.Player)
+	// Draw player, blinking while invulnerable
+	if g.Invulnerable <= 0 || (g.Invulnerable/(100*time.Millisecond))%2 == 0 {
+		r.drawPlayer(grid, g.Player)
+	}
 
 	// Convert grid to string
 	for y := 0; y < r.height-4; y++ { // Leave space for HUD (4 lines: border + stats + wpm + current word)
@@ -129,6 +374,54 @@ func (r *Renderer) renderGameplay(g *Game) string {
 	// Draw HUD
 	sb.WriteString(r.renderHUD(g))
 
+	// The ghost is a faint marker on the platform it reached in the same course
+	if seq, ok := g.ghostPlatform(); ok {
+		for _, platform := range g.Platforms {
+			if platform.Seq != seq || platform.Y-1 >= r.height-4 {
+				continue
+			}
+			x := platform.X + platform.Width/2
+			if x == g.Player.X && platform.Y-1 == g.Player.Y {
+				x++ // Side by side with the player
+			}
+			r.writeAtPosition(&sb, x, platform.Y-1, ColorDim+"@"+ColorReset)
+		}
+	}
+
+	// Wrong characters of the current word are red, and the whole word
+	// flashes red right after a mistake
+	if len(g.Platforms) > 0 && g.Player.Platform < len(g.Platforms) {
+		platform := g.Platforms[g.Player.Platform]
+		if y := platform.Y + 1; !platform.Complete && !platform.Collapsed && y >= 0 && y < r.height-4 {
+			x, label := platformLabel(platform)
+			if g.ErrorFlash > 0 {
+				chars := []rune(label)
+				if x < 0 {
+					chars, x = chars[min(-x, len(chars)):], 0
+				}
+				chars = chars[:max(min(len(chars), r.width-x), 0)]
+				r.writeAtPosition(&sb, x, y, ColorBold+ColorRed+string(chars)+ColorReset)
+			} else {
+				typed := []rune(platform.Typed)
+				for i, wrong := range g.WordManager.typedErrors(platform.Word, platform.Typed) {
+					if wrong && x+1+i >= 0 && x+1+i < r.width {
+						r.writeAtPosition(&sb, x+1+i, y, ColorRed+string(typed[i])+ColorReset)
+					}
+				}
+			}
+		}
+	}
+
+	// Active power-ups and their countdowns sit in the top right corner
+	if effects := g.effectsStatus(); effects != "" {
+		r.writeAtPosition(&sb, r.width-textWidth(effects)-1, 0, ColorBold+ColorYellow+effects+ColorReset)
+	}
+
+	// Streak callouts float over the middle of the playing field
+	if g.CalloutTimer > 0 && g.Callout != "" {
+		r.writeAtPosition(&sb, r.width/2-textWidth(g.Callout)/2, r.height/3, ColorBold+ColorPurple+g.Callout+ColorReset)
+	}
+
 	return sb.String()
 }
 
@@ -143,14 +436,17 @@ func (r *Renderer) renderPaused(g *Game) string {
 	centerY := r.height / 2
 	centerX := r.width / 2
 
-	pauseMsg := "PAUSED"
-	pauseX := centerX - len(pauseMsg)/2
+	pauseMsg := g.tr("PAUSED")
+	pauseX := centerX - textWidth(pauseMsg)/2
 	r.writeAtPosition(&sb, pauseX, centerY-1, ColorBold+ColorYellow+pauseMsg+ColorReset)
 
-	resumeMsg := "Press ESC to resume, Q to quit"
-	resumeX := centerX - len(resumeMsg)/2
+	resumeMsg := g.tr("Press %s to resume, ENTER to end run", g.Bindings.Name(ActionResume))
+	resumeX := centerX - textWidth(resumeMsg)/2
 	r.writeAtPosition(&sb, resumeX, centerY+1, ColorWhite+resumeMsg+ColorReset)
 
+	saveMsg := g.tr("S to save and return to the menu, %s to save and quit", g.Bindings.Name(ActionQuit))
+	r.writeAtPosition(&sb, centerX-textWidth(saveMsg)/2, centerY+2, ColorWhite+saveMsg+ColorReset)
+
 	return sb.String()
 }
 
@@ -164,30 +460,385 @@ func (r *Renderer) renderGameOver(g *Game) string {
 	centerY := r.height / 2
 	centerX := r.width / 2
 
+	if g.showTimeline {
+		r.drawTimeline(&sb, g)
+		return sb.String()
+	}
+
 	// Game Over title
-	gameOverMsg := "GAME OVER"
-	gameOverX := centerX - len(gameOverMsg)/2
-	r.writeAtPosition(&sb, gameOverX, centerY-4, ColorBold+ColorRed+gameOverMsg+ColorReset)
+	gameOverMsg := g.tr("GAME OVER")
+	gameOverX := centerX - textWidth(gameOverMsg)/2
+	r.writeAtPosition(&sb, gameOverX, centerY-6, ColorBold+ColorRed+gameOverMsg+ColorReset)
+
+	reasonMsg := g.tr(g.Mode.Name()) + " - " + g.tr(g.EndReason)
+	if g.Daily {
+		reasonMsg = g.tr("Daily %s", reasonMsg)
+	}
+	r.writeAtPosition(&sb, centerX-textWidth(reasonMsg)/2, centerY-5, ColorYellow+reasonMsg+ColorReset)
+	if name := g.ProfileName(); name != "" {
+		playerMsg := g.tr("Player: %s", name)
+		r.writeAtPosition(&sb, centerX-textWidth(playerMsg)/2, centerY-4, ColorGreen+playerMsg+ColorReset)
+	}
 
 	// Stats
 	stats := g.GetStats()
 	statsLines := []string{
-		fmt.Sprintf("Score: %d", stats.Score),
-		fmt.Sprintf("WPM: %.1f", stats.WPM),
-		fmt.Sprintf("CPM: %.1f", stats.CPM),
-		fmt.Sprintf("Words: %d", stats.WordsTyped),
-		fmt.Sprintf("Time: %s", formatDuration(stats.GameTime)),
+		g.tr("Score: %d", stats.Score),
+		g.tr("WPM: %.1f", stats.WPM),
+		g.tr("CPM: %.1f", stats.CPM),
+		g.tr("Accuracy: %.1f%%", stats.Accuracy),
+		g.tr("Words: %d", stats.WordsTyped),
+		g.tr("Time: %s", formatDuration(stats.GameTime)),
 	}
 
 	for i, line := range statsLines {
-		lineX := centerX - len(line)/2
-		r.writeAtPosition(&sb, lineX, centerY-1+i, ColorWhite+line+ColorReset)
+		lineX := centerX - textWidth(line)/2
+		r.writeAtPosition(&sb, lineX, centerY-3+i, ColorWhite+line+ColorReset)
+	}
+
+	if lead, ok := g.GhostLead(); ok {
+		ghostMsg := g.tr("You beat the ghost by %d words", lead)
+		switch {
+		case lead < 0:
+			ghostMsg = g.tr("The ghost won by %d words", -lead)
+		case lead == 0:
+			ghostMsg = g.tr("A tie with the ghost")
+		}
+		r.writeAtPosition(&sb, centerX-textWidth(ghostMsg)/2, centerY+3, ColorYellow+ghostMsg+ColorReset)
+	}
+
+	// Score breakdown
+	b := g.Breakdown
+	breakdownLines := []string{
+		g.tr("Base %d + Speed %d + Combo %d + Streak %d + Power-ups %d", b.Base, b.Speed, b.Combo, b.Milestone, b.PowerUp),
+		g.tr("Best combo: %d words", g.BestCombo),
+	}
+	for i, line := range breakdownLines {
+		r.writeAtPosition(&sb, centerX-textWidth(line)/2, centerY+4+i, ColorCyan+line+ColorReset)
+	}
+
+	if code, err := EncodeChallenge(g.RunSettings(), g.StartingLives); err == nil {
+		codeMsg := g.tr("Challenge code: %s", code)
+		r.writeAtPosition(&sb, centerX-textWidth(codeMsg)/2, centerY+6, ColorPurple+codeMsg+ColorReset)
+	}
+
+	if g.HighScoreRank > 0 {
+		rankMsg := g.tr("New high score! Rank #%d", g.HighScoreRank)
+		r.writeAtPosition(&sb, centerX-textWidth(rankMsg)/2, centerY+7, ColorBold+ColorCyan+rankMsg+ColorReset)
+	}
+	if g.Daily {
+		dailyMsg := g.tr("Practice run - today's daily was already played")
+		if g.DailyRecorded {
+			dailyMsg = g.tr("Daily result recorded!")
+			if g.DailyHistory != nil {
+				dailyMsg += " " + g.tr("Streak: %d", g.DailyHistory.Streak(time.Now()))
+			}
+		}
+		r.writeAtPosition(&sb, centerX-textWidth(dailyMsg)/2, centerY+8, ColorBold+ColorYellow+dailyMsg+ColorReset)
+	}
+	if lesson, ok := g.currentLesson(); ok && g.LessonResult != nil {
+		lessonMsg := g.tr("Not passed yet, the goal is %s", lesson.Goal(g.Locale))
+		if g.LessonResult.Passed {
+			lessonMsg = g.tr("%s passed!", g.tr(lesson.Name))
+			if next, ok := nextLesson(lesson); ok {
+				lessonMsg += " " + g.tr("%s is unlocked", g.tr(next.Name))
+			}
+		}
+		r.writeAtPosition(&sb, centerX-textWidth(lessonMsg)/2, centerY+8, ColorBold+ColorYellow+lessonMsg+ColorReset)
 	}
 
 	// Options
-	optionsMsg := "Press SPACE to play again, Q to quit"
-	optionsX := centerX - len(optionsMsg)/2
-	r.writeAtPosition(&sb, optionsX, centerY+6, ColorGreen+optionsMsg+ColorReset)
+	optionsMsg := g.tr("%s play again | %s retry this course | T timeline | M menu | %s quit",
+		g.Bindings.Name(ActionStart), g.Bindings.Name(ActionRestart), g.Bindings.Name(ActionQuit))
+	optionsX := centerX - textWidth(optionsMsg)/2
+	r.writeAtPosition(&sb, optionsX, centerY+9, ColorGreen+optionsMsg+ColorReset)
+
+	if g.LastReplayPath != "" {
+		replayMsg := g.tr("Replay saved to %s", g.LastReplayPath)
+		r.writeAtPosition(&sb, centerX-textWidth(replayMsg)/2, centerY+10, ColorWhite+replayMsg+ColorReset)
+	}
+
+	return sb.String()
+}
+
+// drawTimeline draws the game over chart of WPM, accuracy and scroll speed
+// over the run, marking speed ramps and bursts of mistakes
+func (r *Renderer) drawTimeline(sb *strings.Builder, g *Game) {
+	centerY := r.height / 2
+	centerX := r.width / 2
+
+	title := g.tr("RUN TIMELINE")
+	r.writeAtPosition(sb, centerX-textWidth(title)/2, centerY-10, ColorBold+ColorCyan+title+ColorReset)
+
+	options := g.tr("T results | %s play again | %s retry this course | M menu | %s quit",
+		g.Bindings.Name(ActionStart), g.Bindings.Name(ActionRestart), g.Bindings.Name(ActionQuit))
+	r.writeAtPosition(sb, centerX-textWidth(options)/2, centerY+8, ColorGreen+options+ColorReset)
+
+	if len(g.Timeline) < 2 {
+		empty := g.tr("The run was too short for a timeline")
+		r.writeAtPosition(sb, centerX-textWidth(empty)/2, centerY-4, ColorWhite+empty+ColorReset)
+		return
+	}
+
+	const chartRows, labelWidth = 6, 6
+	series := Series(g.Timeline)
+	width := min(len(g.Timeline), r.width-labelWidth-4)
+	wpm := resample(series.WPM, width)
+	accuracy := resample(series.Accuracy, width)
+	speed := resample(series.Speed, width)
+	ramps := resampleMarks(series.Ramps, width)
+	clusters := resampleMarks(series.Clusters, width)
+	width = len(wpm)
+	left := centerX - (width+labelWidth)/2
+
+	// WPM over the last seconds as a point chart with its scale on the left
+	limit := 0.0
+	for _, v := range wpm {
+		limit = max(limit, v)
+	}
+	for i, line := range plot(wpm, chartRows, limit) {
+		label := strings.Repeat(" ", labelWidth-1) + "|"
+		switch i {
+		case 0:
+			label = fmt.Sprintf("%4.0f |", limit)
+		case chartRows - 1:
+			label = "   0 |"
+		}
+		r.writeAtPosition(sb, left, centerY-8+i, ColorWhite+label+ColorReset)
+		r.writeAtPosition(sb, left+labelWidth, centerY-8+i, ColorGreen+line+ColorReset)
+	}
+	axis := strings.Repeat(" ", labelWidth-1) + "+" + strings.Repeat("-", width)
+	r.writeAtPosition(sb, left, centerY-2, ColorWhite+axis+ColorReset)
+
+	// Accuracy and speed as sparklines under the chart
+	r.writeAtPosition(sb, left, centerY-1, ColorWhite+padRight(g.tr("Acc"), labelWidth)+ColorReset+ColorCyan+sparkline(accuracy)+ColorReset)
+	r.writeAtPosition(sb, left, centerY, ColorWhite+padRight(g.tr("Speed"), labelWidth)+ColorReset+ColorYellow+sparkline(speed)+ColorReset)
+
+	// Speed ramps and mistake clusters, clusters win where both happened
+	var marks strings.Builder
+	for c := range ramps {
+		switch {
+		case clusters[c]:
+			marks.WriteString(ColorRed + "x" + ColorReset)
+		case ramps[c]:
+			marks.WriteString(ColorBold + ColorYellow + "^" + ColorReset)
+		default:
+			marks.WriteByte(' ')
+		}
+	}
+	r.writeAtPosition(sb, left, centerY+1, ColorWhite+padRight(g.tr("Marks"), labelWidth)+ColorReset+marks.String())
+
+	end := formatDuration(g.Timeline[len(g.Timeline)-1].Time)
+	r.writeAtPosition(sb, left+labelWidth, centerY+2, ColorWhite+"0:00"+ColorReset)
+	r.writeAtPosition(sb, left+labelWidth+width-len(end), centerY+2, ColorWhite+end+ColorReset)
+
+	rampCount, clusterCount := 0, 0
+	for i := range series.Ramps {
+		if series.Ramps[i] {
+			rampCount++
+		}
+		if series.Clusters[i] {
+			clusterCount++
+		}
+	}
+	legend := g.tr("* WPM over %ds | ^ speed ramp (%d) | x mistake cluster (%d)",
+		timelineWindow, rampCount, clusterCount)
+	r.writeAtPosition(sb, centerX-textWidth(legend)/2, centerY+4, ColorWhite+legend+ColorReset)
+}
+
+// renderDailyHistory renders a calendar of the last weeks of daily challenges
+func (r *Renderer) renderDailyHistory(g *Game) string {
+	var sb strings.Builder
+
+	// Clear screen
+	sb.WriteString("\033[2J\033[H")
+
+	centerY := r.height / 2
+	centerX := r.width / 2
+
+	title := g.tr("DAILY CHALLENGE")
+	r.writeAtPosition(&sb, centerX-textWidth(title)/2, centerY-10, ColorBold+ColorCyan+title+ColorReset)
+
+	history := g.DailyHistory
+	if history == nil {
+		history = &DailyHistory{Results: map[string]DailyResult{}}
+	}
+	today := time.Now().UTC()
+
+	summary := g.tr("Streak: %d | Best streak: %d | Days played: %d",
+		history.Streak(today), history.BestStreak(), len(history.Results))
+	r.writeAtPosition(&sb, centerX-textWidth(summary)/2, centerY-8, ColorYellow+summary+ColorReset)
+
+	// Calendar of the last six weeks, Monday first, with the WPM under each day
+	const cellWidth, weeks = 6, 6
+	left := centerX - cellWidth*7/2
+	for i, name := range []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"} {
+		r.writeAtPosition(&sb, left+i*cellWidth+1, centerY-6, ColorWhite+g.tr(name)+ColorReset)
+	}
+	weekday := (int(today.Weekday()) + 6) % 7 // Days since Monday
+	start := today.AddDate(0, 0, -weekday-7*(weeks-1))
+	for week := 0; week < weeks; week++ {
+		for i := 0; i < 7; i++ {
+			day := start.AddDate(0, 0, week*7+i)
+			if day.After(today) {
+				break
+			}
+			x, y := left+i*cellWidth, centerY-5+week*2
+
+			label := fmt.Sprintf(" %2d ", day.Day())
+			if day.Format(dateLayout) == today.Format(dateLayout) {
+				label = fmt.Sprintf("[%2d]", day.Day())
+			}
+			color, wpm := ColorWhite, "  -"
+			if result, ok := history.Result(day); ok {
+				color, wpm = ColorGreen, fmt.Sprintf("%3.0f", result.WPM)
+			}
+			r.writeAtPosition(&sb, x, y, color+label+ColorReset)
+			r.writeAtPosition(&sb, x, y+1, color+wpm+ColorReset)
+		}
+	}
+
+	// Today's result
+	todayMsg := g.tr("Today: not played yet - press Y in the menu")
+	if result, ok := history.Result(today); ok {
+		todayMsg = g.tr("Today: %d points | %.1f WPM | %.1f%% accuracy | %d words",
+			result.Score, result.WPM, result.Accuracy, result.Words)
+	}
+	r.writeAtPosition(&sb, centerX-textWidth(todayMsg)/2, centerY+8, ColorCyan+todayMsg+ColorReset)
+
+	backMsg := g.tr("Press any key to return to the menu")
+	r.writeAtPosition(&sb, centerX-textWidth(backMsg)/2, centerY+10, ColorGreen+backMsg+ColorReset)
+
+	return sb.String()
+}
+
+// renderProfiles renders the list of profiles and the profile actions
+func (r *Renderer) renderProfiles(g *Game) string {
+	var sb strings.Builder
+
+	// Clear screen
+	sb.WriteString("\033[2J\033[H")
+
+	centerY := r.height / 2
+	centerX := r.width / 2
+
+	title := g.tr("PROFILES")
+	r.writeAtPosition(&sb, centerX-textWidth(title)/2, centerY-8, ColorBold+ColorCyan+title+ColorReset)
+
+	r.drawMenu(&sb, g.Locale, g.profilesMenu, centerX, centerY-6, 9)
+
+	var prompt, hint string
+	switch g.profileAction {
+	case profileActionCreate:
+		prompt, hint = g.tr("New profile: "), g.tr("Type a name, ENTER to create, ESC to cancel")
+	case profileActionRename:
+		prompt, hint = g.tr("Rename to: "), g.tr("Type a name, ENTER to rename, ESC to cancel")
+	case profileActionImport:
+		prompt, hint = g.tr("Import file: "), g.tr("Type the path of an exported profile, ENTER to import, ESC to cancel")
+	case profileActionDelete:
+		hint = g.tr("Delete %s and all of its scores? Y to confirm, any other key to cancel", g.ProfileName())
+	default:
+		hint = g.tr("UP/DOWN select | ENTER switch | N new | R rename | X delete | E export | I import | ESC back")
+	}
+	if prompt != "" {
+		prompt += g.profileInput + "_"
+		r.writeAtPosition(&sb, centerX-textWidth(prompt)/2, centerY+4, ColorBold+ColorYellow+prompt+ColorReset)
+	}
+	r.writeAtPosition(&sb, centerX-textWidth(hint)/2, centerY+5, ColorWhite+hint+ColorReset)
+	if g.MenuMessage != "" {
+		r.writeAtPosition(&sb, centerX-textWidth(g.MenuMessage)/2, centerY+7, ColorCyan+g.MenuMessage+ColorReset)
+	}
+
+	return sb.String()
+}
+
+// renderProgress renders charts of the recent sessions and of the last days
+func (r *Renderer) renderProgress(g *Game) string {
+	var sb strings.Builder
+
+	// Clear screen
+	sb.WriteString("\033[2J\033[H")
+
+	centerY := r.height / 2
+	centerX := r.width / 2
+
+	title := g.tr("PROGRESS")
+	r.writeAtPosition(&sb, centerX-textWidth(title)/2, centerY-10, ColorBold+ColorCyan+title+ColorReset)
+
+	sessions := g.progressSessions()
+	modeName := g.tr("All modes")
+	if mode, ok := ModeByID(g.progressMode); ok {
+		modeName = g.tr(mode.Name())
+	}
+	count := max(min(progressCounts[g.progressCount], r.width-40), 1)
+	recent := sessions[max(len(sessions)-count, 0):]
+	summary := modeName + " | " + g.tr("Last %d of %d sessions", len(recent), len(sessions))
+	r.writeAtPosition(&sb, centerX-textWidth(summary)/2, centerY-9, ColorYellow+summary+ColorReset)
+
+	// Sparklines of the recent sessions, carets mark personal bests
+	left := centerX - (count+30)/2
+	if len(recent) == 0 {
+		empty := g.tr("No finished runs yet - play one and come back")
+		r.writeAtPosition(&sb, centerX-textWidth(empty)/2, centerY-6, ColorWhite+empty+ColorReset)
+	}
+	labelWidth := 0
+	for _, metric := range progressMetrics {
+		labelWidth = max(labelWidth, textWidth(g.tr(metric.Name))+1)
+	}
+	for i, metric := range progressMetrics {
+		if len(recent) == 0 {
+			break
+		}
+		values := metric.values(sessions)
+		bests := personalBests(values)[len(sessions)-len(recent):]
+		values = values[len(sessions)-len(recent):]
+		best := values[0]
+		for _, v := range values {
+			best = max(best, v)
+		}
+
+		y := centerY - 7 + i*2
+		label := padRight(g.tr(metric.Name), labelWidth)
+		r.writeAtPosition(&sb, left, y, ColorWhite+label+ColorReset)
+		r.writeAtPosition(&sb, left+textWidth(label), y, ColorGreen+sparkline(values)+ColorReset)
+		last := " " + g.tr("last %s best %s", fmt.Sprintf(metric.Format, values[len(values)-1]), fmt.Sprintf(metric.Format, best))
+		r.writeAtPosition(&sb, left+textWidth(label)+len(values), y, ColorWhite+last+ColorReset)
+		r.writeAtPosition(&sb, left+textWidth(label), y+1, ColorBold+ColorYellow+markers(bests)+ColorReset)
+	}
+
+	// Bars of the daily averages, PB marks the days a personal best was set
+	metric := progressMetrics[g.progressMetric]
+	today := g.now()
+	days := ByDay(sessions, today, progressDays)
+	bestDays := make(map[string]bool)
+	for i, isBest := range personalBests(metric.values(sessions)) {
+		if isBest {
+			bestDays[sessions[i].Date.In(today.Location()).Format(dateLayout)] = true
+		}
+	}
+	limit := 0.0
+	for _, day := range days {
+		limit = max(limit, metric.Day(day))
+	}
+	heading := g.tr("%s by day (average)", g.tr(metric.Name))
+	r.writeAtPosition(&sb, left, centerY, ColorWhite+heading+ColorReset)
+	for i, day := range days {
+		date, _ := time.ParseInLocation(dateLayout, day.Date, today.Location())
+		line := fmt.Sprintf("%s %s |%s|", date.Format("Jan 02"), g.tr(date.Format("Mon")), bar(metric.Day(day), limit, 30))
+		if day.Runs > 0 {
+			line += fmt.Sprintf(" "+metric.Format+" (%d)", metric.Day(day), day.Runs)
+		}
+		if bestDays[day.Date] {
+			line += " PB"
+		}
+		r.writeAtPosition(&sb, left, centerY+1+i, ColorGreen+line+ColorReset)
+	}
+
+	hint := g.tr("M mode | N sessions | C chart | E export | ESC back")
+	r.writeAtPosition(&sb, centerX-textWidth(hint)/2, centerY+9, ColorWhite+hint+ColorReset)
+	if g.MenuMessage != "" {
+		r.writeAtPosition(&sb, centerX-textWidth(g.MenuMessage)/2, centerY+10, ColorCyan+g.MenuMessage+ColorReset)
+	}
 
 	return sb.String()
 }
@@ -199,29 +850,56 @@ func (r *Renderer) renderHUD(g *Game) string {
 	// Top border
 	border := strings.Repeat("=", r.width)
 
-	// HUD line 1: Score and time
-	gameTime := time.Since(g.StartTime)
-	line1 := fmt.Sprintf("Score: %d | Time: %s", stats.Score, formatDuration(gameTime))
+	// HUD line 1: Mode, score and time
+	line1 := g.tr(g.Mode.Name()) + " | " + g.tr("Score: %d", stats.Score) + " | " + g.tr("Time: %s", formatDuration(stats.GameTime))
+	if name := g.ProfileName(); name != "" {
+		line1 = name + " | " + line1
+	}
+	if status := g.Mode.Status(g); status != "" {
+		line1 += " | " + status
+	}
+	if status := g.ghostStatus(); status != "" {
+		line1 += " | " + status
+	}
+	if g.LivesEnabled() {
+		line1 += " | " + g.tr("Lives: %s", strings.Repeat("<3 ", g.Lives))
+		if g.Invulnerable > 0 {
+			line1 += g.tr("(safe)")
+		}
+	}
 
-	// HUD line 2: WPM and CPM
-	line2 := fmt.Sprintf("WPM: %.1f | CPM: %.1f | Words: %d", stats.WPM, stats.CPM, stats.WordsTyped)
+	// HUD line 2: WPM, CPM, accuracy and combo
+	line2 := g.tr("WPM: %.1f | CPM: %.1f | Acc: %.1f%% | Words: %d | Combo: %d (x%.1f)",
+		stats.WPM, stats.CPM, stats.Accuracy, stats.WordsTyped, g.Combo, g.ComboMultiplier())
 
 	// Current word display - always show status
 	currentWord := ""
 	if len(g.Platforms) > 0 && g.Player.Platform < len(g.Platforms) {
 		platform := g.Platforms[g.Player.Platform]
 		if !platform.Complete {
-			// Show current word with progress highlighting
-			typed := ColorGreen + "[" + platform.Typed + "]" + ColorReset
-			remaining := ColorWhite + platform.Word[len(platform.Typed):] + ColorReset
-			currentWord = fmt.Sprintf("Word: %s%s", typed, remaining)
+			// Show current word with progress highlighting, wrong characters in red
+			var typed strings.Builder
+			chars := []rune(platform.Typed)
+			for i, wrong := range g.WordManager.typedErrors(platform.Word, platform.Typed) {
+				color := ColorGreen
+				if wrong {
+					color = ColorRed
+				}
+				typed.WriteString(color + string(chars[i]))
+			}
+			remainingColor := ColorWhite
+			if g.ErrorFlash > 0 {
+				remainingColor = ColorBold + ColorRed
+			}
+			remaining := remainingColor + untypedPart(platform) + ColorReset
+			currentWord = g.tr("Word: %s", ColorGreen+"["+typed.String()+ColorGreen+"]"+remaining)
 		} else {
 			// Show completed word in green
-			currentWord = fmt.Sprintf("Word: %s%s%s (Complete!)", ColorGreen, platform.Word, ColorReset)
+			currentWord = g.tr("Word: %s", ColorGreen+platform.Word+ColorReset+" "+g.tr("(Complete!)"))
 		}
 	} else {
 		// No active platform
-		currentWord = "Word: " + ColorYellow + "No active word" + ColorReset
+		currentWord = g.tr("Word: %s", ColorYellow+g.tr("No active word")+ColorReset)
 	}
 
 	return fmt.Sprintf("%s\n%s\n%s\n%s\n",
@@ -241,25 +919,40 @@ func (r *Renderer) writeAtPosition(sb *strings.Builder, x, y int, text string) {
 func (r *Renderer) drawPlatform(grid [][]rune, platform Platform) {
 	// Platform Y position is now its actual screen position
 	screenY := platform.Y
+	if platform.Collapsed {
+		return
+	}
 
 	// Only draw if platform is visible on screen
 	if screenY >= 0 && screenY < len(grid)-3 {
-		// Draw platform line
+		// Draw platform line, checkpoints and power-ups stand out so players can aim for them
+		glyph := platform.Glyph()
+		if platform.PowerUp != PowerUpNone {
+			glyph = platform.PowerUp.Glyph()
+		}
+		if platform.Checkpoint {
+			glyph = '#'
+		}
 		for i := 0; i < platform.Width && platform.X+i < len(grid[screenY]); i++ {
 			if platform.X+i >= 0 {
-				grid[screenY][platform.X+i] = '='
+				grid[screenY][platform.X+i] = glyph
+			}
+		}
+
+		// Movers get arrow heads so their direction is readable
+		if platform.Type == PlatformMoving && platform.Width > 1 {
+			if platform.X >= 0 && platform.X < len(grid[screenY]) {
+				grid[screenY][platform.X] = '<'
+			}
+			if end := platform.X + platform.Width - 1; end >= 0 && end < len(grid[screenY]) {
+				grid[screenY][end] = '>'
 			}
 		}
 
 		// Draw word below platform with typed indicator
 		if screenY+1 < len(grid)-3 && !platform.Complete {
-			// Create display string with typed characters in brackets
-			typedPart := "[" + platform.Typed + "]"
-			remainingPart := platform.Word[len(platform.Typed):]
-			displayWord := typedPart + remainingPart
-
-			wordX := platform.X + platform.Width/2 - len(displayWord)/2
-			for i, char := range displayWord {
+			wordX, displayWord := platformLabel(platform)
+			for i, char := range []rune(displayWord) {
 				if wordX+i >= 0 && wordX+i < len(grid[screenY+1]) {
 					grid[screenY+1][wordX+i] = char
 				}
@@ -268,6 +961,19 @@ func (r *Renderer) drawPlatform(grid [][]rune, platform Platform) {
 	}
 }
 
+// platformLabel returns the word drawn below a platform, with the typed
+// characters in brackets, and the column it starts at
+func platformLabel(platform Platform) (int, string) {
+	label := "[" + platform.Typed + "]" + untypedPart(platform)
+	return platform.X + platform.Width/2 - utf8.RuneCountInString(label)/2, label
+}
+
+// untypedPart returns the characters of the platform's word past the typed ones
+func untypedPart(platform Platform) string {
+	word := []rune(platform.Word)
+	return string(word[min(utf8.RuneCountInString(platform.Typed), len(word)):])
+}
+
 func (r *Renderer) drawPlayer(grid [][]rune, player Player) {
 	// Player Y position is now its actual screen position
 	screenY := player.Y
@@ -277,11 +983,35 @@ func (r *Renderer) drawPlayer(grid [][]rune, player Player) {
 	}
 }
 
+// padString pads or cuts s to width columns, color codes taking none
 func (r *Renderer) padString(s string, width int) string {
-	if len(s) >= width {
-		return s[:width]
+	var sb strings.Builder
+	columns, escape := 0, false
+	for _, ch := range s {
+		switch {
+		case escape:
+			escape = ch != 'm'
+		case ch == '\033':
+			escape = true
+		case columns+runewidth.RuneWidth(ch) > width:
+			return sb.String()
+		default:
+			columns += runewidth.RuneWidth(ch)
+		}
+		sb.WriteRune(ch)
 	}
-	return s + strings.Repeat(" ", width-len(s))
+	return sb.String() + strings.Repeat(" ", width-columns)
+}
+
+// textWidth returns how many terminal columns text takes, counting wide
+// characters twice and combining marks not at all
+func textWidth(text string) int {
+	return runewidth.StringWidth(text)
+}
+
+// padRight pads text with spaces to width columns, like %-*s does for bytes
+func padRight(text string, width int) string {
+	return text + strings.Repeat(" ", max(width-textWidth(text), 0))
 }
 
 func formatDuration(d time.Duration) string {
@@ -0,0 +1,230 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	replayVersion   = 1
+	framesPerSecond = 60 // frames per second of game time, see updateGameLogic
+)
+
+// ErrInvalidReplay is returned for replay files that can't be played back
+var ErrInvalidReplay = errors.New("invalid replay")
+
+// Replay is a recorded run: the settings that determine the course and every
+// input and resize, stamped with the frame it happened after and its time.
+// Played back through the engine it reproduces the run exactly.
+type Replay struct {
+	Version  int                 `json:"version"`
+	Settings RunSettings         `json:"settings"`
+	Lives    int                 `json:"lives"`
+	Tuning   Tuning              `json:"tuning"`
+	Bindings map[string][]string `json:"bindings,omitempty"`       // remapped actions the keys were read with
+	Errors   ErrorPolicy         `json:"errors,omitempty"`         // empty for runs from before error policies
+	Accents  bool                `json:"ignore_accents,omitempty"` // accented letters matched the plain ones
+	Width    int                 `json:"width"`
+	Height   int                 `json:"height"`
+	Start    time.Time           `json:"start"` // wall time the run started
+	Events   []ReplayEvent       `json:"events"`
+	EndFrame int                 `json:"end_frame"` // frame the run ended on
+	End      time.Duration       `json:"end"`       // time the run ended, since Start
+	Reason   string              `json:"reason"`    // why the run ended
+	Stats    Stats               `json:"stats"`     // final stats of the run
+}
+
+// ReplayEvent is a single key press or, if Width is set, a terminal resize
+type ReplayEvent struct {
+	Frame  int           `json:"f"` // frames rendered since the run started
+	Time   time.Duration `json:"t"` // since the start of the run
+	Key    rune          `json:"k,omitempty"`
+	Width  int           `json:"w,omitempty"`
+	Height int           `json:"h,omitempty"`
+}
+
+// IsResize reports whether the event is a terminal resize
+func (e ReplayEvent) IsResize() bool {
+	return e.Width > 0
+}
+
+// LoadReplay reads a replay file
+func LoadReplay(path string) (*Replay, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	replay := &Replay{}
+	if err := json.Unmarshal(data, replay); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidReplay, err)
+	}
+	if err := replay.Validate(); err != nil {
+		return nil, err
+	}
+	return replay, nil
+}
+
+// Save writes the replay to path
+func (r *Replay) Save(path string) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Validate checks that the replay can be played back by this build
+func (r *Replay) Validate() error {
+	if r.Version != replayVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidReplay, r.Version)
+	}
+	if err := r.Settings.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidReplay, err)
+	}
+	if err := r.Tuning.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidReplay, err)
+	}
+	if _, err := ParseBindings(r.Bindings); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidReplay, err)
+	}
+	if _, ok := ErrorPolicyByID(string(r.RunErrors())); !ok {
+		return fmt.Errorf("%w: unknown error policy %q", ErrInvalidReplay, r.Errors)
+	}
+	if r.Width <= 0 || r.Height <= 0 {
+		return fmt.Errorf("%w: bad screen size %dx%d", ErrInvalidReplay, r.Width, r.Height)
+	}
+	frame := 0
+	for i, event := range r.Events {
+		if event.Frame < frame || event.Frame > r.EndFrame {
+			return fmt.Errorf("%w: event %d is out of order", ErrInvalidReplay, i)
+		}
+		frame = event.Frame
+	}
+	return nil
+}
+
+// RunErrors returns the error policy the run was played with
+func (r *Replay) RunErrors() ErrorPolicy {
+	if r.Errors == "" {
+		return ErrorsStop
+	}
+	return r.Errors
+}
+
+// Duration returns the game time the replay covers
+func (r *Replay) Duration() time.Duration {
+	return frameDuration(r.EndFrame)
+}
+
+// frameTime returns the wall time of the given frame, since Start. Only the
+// times of events and of the end are recorded, frames in between are spread
+// evenly. Event positions are doubled so an event after frame f sits at 2f+1.
+func (r *Replay) frameTime(frame int) time.Duration {
+	lowPos, lowTime := 0, time.Duration(0)
+	highPos, highTime := 2*r.EndFrame, r.End
+	pos := 2 * frame
+	for _, event := range r.Events {
+		eventPos := 2*event.Frame + 1
+		if eventPos < pos {
+			lowPos, lowTime = eventPos, event.Time
+		} else {
+			highPos, highTime = eventPos, event.Time
+			break
+		}
+	}
+	if pos >= highPos || highPos <= lowPos {
+		return highTime
+	}
+	return lowTime + (highTime-lowTime)*time.Duration(pos-lowPos)/time.Duration(highPos-lowPos)
+}
+
+// frameDuration converts a number of frames to game time
+func frameDuration(frames int) time.Duration {
+	return time.Duration(frames) * time.Second / framesPerSecond
+}
+
+// startRecording begins recording the run that was just reset
+func (g *Game) startRecording() {
+	g.recording = &Replay{
+		Version:  replayVersion,
+		Settings: g.RunSettings(),
+		Lives:    g.StartingLives,
+		Tuning:   g.Tuning,
+		Bindings: g.Bindings.Names(),
+		Errors:   g.Errors,
+		Accents:  g.WordManager.IgnoreAccents,
+		Width:    g.Width,
+		Height:   g.Height,
+		Start:    g.StartTime,
+	}
+}
+
+// recordEvent adds an input or resize to the run being recorded
+func (g *Game) recordEvent(event ReplayEvent) {
+	if g.recording == nil {
+		return
+	}
+	event.Frame = g.frame
+	event.Time = g.now().Sub(g.recording.Start)
+	g.recording.Events = append(g.recording.Events, event)
+}
+
+// finishRecording completes the replay of the run that just ended and saves
+// it to ReplayDir, if set
+func (g *Game) finishRecording() {
+	replay := g.recording
+	if replay == nil {
+		return
+	}
+	g.recording = nil
+	replay.EndFrame = g.frame
+	replay.End = g.endedAt.Sub(replay.Start)
+	replay.Reason = g.EndReason
+	replay.Stats = g.GetStats()
+	g.LastReplay = replay
+
+	if g.ReplayDir == "" {
+		return
+	}
+	if err := os.MkdirAll(g.ReplayDir, 0755); err != nil {
+		g.Logger.Printf("finishRecording: failed to create replay directory: %v", err)
+		return
+	}
+	path, err := replay.saveNew(g.ReplayDir)
+	if err != nil {
+		g.Logger.Printf("finishRecording: failed to save replay: %v", err)
+		path = ""
+	}
+	g.LastReplayPath = path
+}
+
+// saveNew writes the replay to a new file in dir named after its start and
+// mode. Runs started within the same second are numbered, so a replay never
+// overwrites another one a high score may point to.
+func (r *Replay) saveNew(dir string) (string, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return "", err
+	}
+	base := filepath.Join(dir, fmt.Sprintf("%s-%s", r.Start.Format("20060102-150405"), r.Settings.Mode))
+	path := base + ".json"
+	for n := 2; ; n++ {
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if errors.Is(err, os.ErrExist) {
+			path = fmt.Sprintf("%s-%d.json", base, n)
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		_, err = file.Write(data)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		return path, err
+	}
+}
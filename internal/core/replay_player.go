@@ -0,0 +1,196 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const seekStep = 5 * time.Second // how far [ and ] seek
+
+// replaySpeeds are the playback speeds, in frames played per rendered frame
+var replaySpeeds = []int{1, 2, 4}
+
+// ReplayPlayer plays a replay back through a real Game. It implements
+// GameInterface, so the terminal client can show it like the live game.
+type ReplayPlayer struct {
+	replay *Replay
+	game   *Game
+	clock  *ManualClock
+	next   int // index of the next event to play
+	speed  int
+	paused bool
+	done   bool // the run has ended
+	quit   bool
+}
+
+// NewReplayPlayer prepares game to play back replay. The game only reads its
+// own inputs from the replay and never saves high scores or daily results.
+func NewReplayPlayer(game *Game, replay *Replay) (*ReplayPlayer, error) {
+	if err := replay.Validate(); err != nil {
+		return nil, err
+	}
+	clock := NewManualClock(replay.Start)
+	game.Clock = clock
+	bindings, _ := ParseBindings(replay.Bindings) // Checked by Validate
+	game.SetBindings(bindings)
+	game.HighScores = nil
+	game.DailyHistory = nil
+	game.Lessons = nil
+	game.ReplayDir = ""
+	return &ReplayPlayer{
+		replay: replay,
+		game:   game,
+		clock:  clock,
+		speed:  1,
+	}, nil
+}
+
+// Game returns the game the replay is played through
+func (p *ReplayPlayer) Game() *Game {
+	return p.game
+}
+
+// SetSpeed sets the playback speed, one of 1, 2 or 4
+func (p *ReplayPlayer) SetSpeed(speed int) {
+	for _, s := range replaySpeeds {
+		if s == speed {
+			p.speed = speed
+		}
+	}
+}
+
+// Start sets up the recorded run. The replay keeps its recorded screen size,
+// whatever the size of the terminal it is shown in.
+func (p *ReplayPlayer) Start(width, height int) {
+	p.game.Start(p.replay.Width, p.replay.Height)
+	p.restart()
+}
+
+// UpdateDimensions ignores terminal resizes, the recorded ones are replayed
+func (p *ReplayPlayer) UpdateDimensions(width, height int) {}
+
+// ProcessInput handles the playback controls
+func (p *ReplayPlayer) ProcessInput(key rune) {
+	switch key {
+	case ' ':
+		p.paused = !p.paused
+	case '1', '2', '4':
+		p.SetSpeed(int(key - '0'))
+	case '[':
+		p.Seek(p.Position() - seekStep)
+	case ']':
+		p.Seek(p.Position() + seekStep)
+	case '.': // Single frame forward while paused
+		if p.paused {
+			p.step()
+		}
+	case 'r', 'R':
+		p.restart()
+	case 'q', 'Q', 27:
+		p.quit = true
+	}
+}
+
+// Render plays the next frames at the current speed and returns the game's
+// frame with the playback status on top
+func (p *ReplayPlayer) Render() string {
+	if !p.paused {
+		for i := 0; i < p.speed && p.step(); i++ {
+		}
+	}
+	frame := p.game.Renderer.RenderGame(p.game)
+	return frame + p.status()
+}
+
+// ShouldQuit returns whether playback was quit
+func (p *ReplayPlayer) ShouldQuit() bool {
+	return p.quit
+}
+
+// Position returns the game time played so far
+func (p *ReplayPlayer) Position() time.Duration {
+	return frameDuration(p.game.frame)
+}
+
+// Done reports whether the whole replay has been played
+func (p *ReplayPlayer) Done() bool {
+	return p.done
+}
+
+// Seek jumps to the given game time. Runs are deterministic, so seeking
+// backwards simply plays the replay again from the start.
+func (p *ReplayPlayer) Seek(position time.Duration) {
+	target := int(position * framesPerSecond / time.Second)
+	if target < p.game.frame {
+		p.restart()
+	}
+	for p.game.frame < target && p.step() {
+	}
+}
+
+// PlayAll plays the rest of the replay without rendering
+func (p *ReplayPlayer) PlayAll() {
+	for p.step() {
+	}
+}
+
+// restart sets the game up exactly as the recorded run started
+func (p *ReplayPlayer) restart() {
+	r, g := p.replay, p.game
+	p.clock.Set(r.Start)
+	p.next = 0
+	p.done = false
+	g.UpdateDimensions(r.Width, r.Height)
+	g.ApplySettings(r.Settings)
+	g.SetTuning(r.Tuning)
+	g.Errors = r.RunErrors()
+	g.WordManager.IgnoreAccents = r.Accents
+	g.StartingLives = r.Lives
+	g.Daily = false
+	g.State = StatePlaying
+	g.reset()
+	g.recording = nil // A replay doesn't record itself
+}
+
+// step plays the events recorded after the current frame, then the next
+// frame. It returns false once the run has ended.
+func (p *ReplayPlayer) step() bool {
+	r, g := p.replay, p.game
+	if p.done {
+		return false
+	}
+	for p.next < len(r.Events) && r.Events[p.next].Frame == g.frame {
+		event := r.Events[p.next]
+		p.clock.Set(r.Start.Add(event.Time))
+		if event.IsResize() {
+			g.UpdateDimensions(event.Width, event.Height)
+		} else {
+			g.ProcessInput(event.Key)
+		}
+		p.next++
+	}
+	if g.State == StateGameOver || g.frame >= r.EndFrame {
+		p.done = true
+		return false
+	}
+	p.clock.Set(r.Start.Add(r.frameTime(g.frame + 1)))
+	g.step()
+	return true
+}
+
+// status renders the playback controls over the bottom line
+func (p *ReplayPlayer) status() string {
+	state := fmt.Sprintf("%dx", p.speed)
+	if p.done {
+		state = "END"
+	} else if p.paused {
+		state = "PAUSED"
+	}
+	line := fmt.Sprintf(" REPLAY %s | %s / %s | SPACE pause | 1 2 4 speed | [ ] seek | . step | R restart | Q quit ",
+		state, formatDuration(p.Position()), formatDuration(p.replay.Duration()))
+
+	var sb strings.Builder
+	p.game.Renderer.writeAtPosition(&sb, 0, p.game.Height-1, ColorBold+ColorPurple+line+ColorReset)
+	return sb.String()
+}
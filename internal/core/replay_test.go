@@ -0,0 +1,267 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordRun plays 20 seconds of a sprint on a manual clock, typing with uneven
+// gaps, a few mistakes, a pause and a resize, and returns the finished game
+func recordRun(t *testing.T) *Game {
+	t.Helper()
+	clock := NewManualClock(time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC))
+	game := newMenuGame(t, func(g *Game) {
+		g.DailyHistory = nil
+		g.Clock = clock
+	})
+	game.ApplySettings(RunSettings{Mode: "sprint60", Pack: "common", Difficulty: 2, Seed: 4242})
+	game.ProcessInput(' ')
+
+	for frame := 0; game.State != StateGameOver && frame < 10000; frame++ {
+		clock.Advance(time.Duration(15+frame%4) * time.Millisecond)
+		game.Render()
+
+		switch {
+		case frame == 300:
+			game.UpdateDimensions(90, 30)
+		case frame == 600:
+			game.ProcessInput(27) // Pause
+		case frame == 660:
+			clock.Advance(3 * time.Second)
+			game.ProcessInput(27) // Resume
+		case frame == 1200:
+			game.ProcessInput(27) // Pause and end the run
+			clock.Advance(time.Second)
+			game.ProcessInput('\r')
+		case frame%3 == 0 && game.State == StatePlaying:
+			platform := game.Platforms[game.Player.Platform]
+			if platform.Complete {
+				continue
+			}
+			key := rune(platform.Word[len(platform.Typed)])
+			if frame%37 == 0 {
+				key = '#' // A mistake, words never contain it
+			} else if frame%41 == 0 {
+				key = 'q' + 'z' - key // Most likely a mistake
+			}
+			clock.Advance(time.Duration(frame%7) * time.Millisecond)
+			game.ProcessInput(key)
+		}
+	}
+	if game.State != StateGameOver {
+		t.Fatal("Expected the run to end")
+	}
+	return game
+}
+
+var (
+	sharedReplayOnce sync.Once
+	sharedReplay     *Replay
+)
+
+// recordedReplay returns the replay of a recordRun, recorded once per test binary
+func recordedReplay(t *testing.T) *Replay {
+	t.Helper()
+	sharedReplayOnce.Do(func() {
+		sharedReplay = recordRun(t).LastReplay
+	})
+	return sharedReplay
+}
+
+func TestReplayReproducesRun(t *testing.T) {
+	live := recordRun(t)
+	replay := live.LastReplay
+	if replay == nil {
+		t.Fatal("Expected the finished run to be recorded")
+	}
+	if replay.Stats.WordsTyped == 0 {
+		t.Fatal("Expected the recorded run to have typed words")
+	}
+
+	path := filepath.Join(t.TempDir(), "run.json")
+	if err := replay.Save(path); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	loaded, err := LoadReplay(path)
+	if err != nil {
+		t.Fatalf("LoadReplay() error: %v", err)
+	}
+
+	game := newTestGame(t)
+	player, err := NewReplayPlayer(game, loaded)
+	if err != nil {
+		t.Fatalf("NewReplayPlayer() error: %v", err)
+	}
+	player.Start(120, 40)
+	player.PlayAll()
+
+	if !player.Done() || game.State != StateGameOver {
+		t.Fatalf("Expected the replay to end the run, state=%v", game.State)
+	}
+	if got := game.GetStats(); got != live.GetStats() {
+		t.Errorf("Expected replayed stats %+v, got %+v", live.GetStats(), got)
+	}
+	if game.Breakdown != live.Breakdown || game.EndReason != live.EndReason {
+		t.Errorf("Expected %+v (%s), got %+v (%s)", live.Breakdown, live.EndReason, game.Breakdown, game.EndReason)
+	}
+	if game.Width != 90 || game.Height != 30 {
+		t.Errorf("Expected the recorded resize to be replayed, got %dx%d", game.Width, game.Height)
+	}
+	if got, want := game.Renderer.RenderGame(game), live.Renderer.RenderGame(live); got != want {
+		t.Error("Expected the replay to render the same game over screen")
+	}
+}
+
+func TestReplayReproducesFall(t *testing.T) {
+	live := newPlayingGame(t)
+	clock := NewManualClock(time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC))
+	live.Clock = clock
+	live.State = StateMenu
+	live.ProcessInput(' ')
+	typeCurrentWord(live, true)
+
+	// Stop typing until the platform scrolls off the bottom
+	for i := 0; i < 10000 && live.State == StatePlaying; i++ {
+		clock.Advance(17 * time.Millisecond)
+		live.Render()
+	}
+	if live.State != StateGameOver {
+		t.Fatal("Expected the player to fall")
+	}
+
+	game := newTestGame(t)
+	player, err := NewReplayPlayer(game, live.LastReplay)
+	if err != nil {
+		t.Fatalf("NewReplayPlayer() error: %v", err)
+	}
+	player.Start(80, 24)
+	player.PlayAll()
+	if got := game.GetStats(); got != live.GetStats() || game.EndReason != live.EndReason {
+		t.Errorf("Expected replayed stats %+v (%s), got %+v (%s)", live.GetStats(), live.EndReason, got, game.EndReason)
+	}
+}
+
+func TestReplaySeek(t *testing.T) {
+	replay := recordedReplay(t)
+
+	game := newTestGame(t)
+	player, err := NewReplayPlayer(game, replay)
+	if err != nil {
+		t.Fatalf("NewReplayPlayer() error: %v", err)
+	}
+	player.Start(80, 24)
+
+	player.Seek(10 * time.Second)
+	score, words := game.Score, game.WordsTyped
+	if player.Position() != 10*time.Second {
+		t.Errorf("Expected position 0:10, got %v", player.Position())
+	}
+
+	// Seeking back replays from the start and arrives at the same state
+	player.Seek(15 * time.Second)
+	player.Seek(10 * time.Second)
+	if game.Score != score || game.WordsTyped != words {
+		t.Errorf("Expected score %d and %d words after seeking back, got %d and %d",
+			score, words, game.Score, game.WordsTyped)
+	}
+
+	// Faster playback plays more frames per render
+	player.SetSpeed(4)
+	player.Render()
+	if want := 10*time.Second + frameDuration(4); player.Position() != want {
+		t.Errorf("Expected position %v at 4x, got %v", want, player.Position())
+	}
+	player.ProcessInput(' ')
+	player.Render()
+	if want := 10*time.Second + frameDuration(4); player.Position() != want {
+		t.Errorf("Expected a paused replay to stay at %v, got %v", want, player.Position())
+	}
+}
+
+func TestReplayFrameTime(t *testing.T) {
+	replay := &Replay{
+		EndFrame: 100,
+		End:      2 * time.Second,
+		Events:   []ReplayEvent{{Frame: 9, Time: time.Second}},
+	}
+	tests := []struct {
+		frame int
+		want  time.Duration
+	}{
+		{0, 0},
+		{100, 2 * time.Second},
+		{5, time.Second * 10 / 19},
+		{10, time.Second + time.Second/181},
+	}
+	for _, tt := range tests {
+		got := replay.frameTime(tt.frame)
+		if diff := got - tt.want; diff < -time.Microsecond || diff > time.Microsecond {
+			t.Errorf("frameTime(%d) = %v, expected %v", tt.frame, got, tt.want)
+		}
+	}
+}
+
+func TestLoadReplayRejectsUnknownVersion(t *testing.T) {
+	replay := &Replay{
+		Version:  99,
+		Settings: RunSettings{Mode: "survival", Pack: "classic", Difficulty: 1, Seed: 1},
+		Width:    80,
+		Height:   24,
+	}
+	path := filepath.Join(t.TempDir(), "run.json")
+	if err := replay.Save(path); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	if _, err := LoadReplay(path); !errors.Is(err, ErrInvalidReplay) {
+		t.Errorf("Expected ErrInvalidReplay, got %v", err)
+	}
+}
+
+func TestLoadReplayRequiresTuning(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.json")
+	data := `{"version": 1, "settings": {"mode": "survival", "pack": "classic", "difficulty": 1, "seed": 1}, "width": 80, "height": 24}`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadReplay(path); !errors.Is(err, ErrInvalidReplay) {
+		t.Errorf("Expected ErrInvalidReplay for a replay without tuning, got %v", err)
+	}
+}
+
+func TestReplaysStartedInTheSameSecondAreKept(t *testing.T) {
+	game := newPlayingGame(t)
+	game.ReplayDir = t.TempDir()
+	start := time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC)
+
+	var paths []string
+	for i := 0; i < 3; i++ {
+		game.recording = &Replay{Version: replayVersion, Settings: game.RunSettings(), Start: start}
+		game.finishRecording()
+		if game.LastReplayPath == "" {
+			t.Fatalf("Expected replay %d to be saved", i)
+		}
+		paths = append(paths, game.LastReplayPath)
+	}
+	if paths[0] == paths[1] || paths[1] == paths[2] || paths[0] == paths[2] {
+		t.Errorf("Expected every replay in its own file, got %v", paths)
+	}
+	entries, _ := os.ReadDir(game.ReplayDir)
+	if len(entries) != 3 {
+		t.Errorf("Expected 3 replay files, got %d", len(entries))
+	}
+}
+
+func TestPlaybackGameHasNoFiles(t *testing.T) {
+	game, err := NewPlaybackGame(filepath.Join(t.TempDir(), "replay_log.txt"))
+	if err != nil {
+		t.Fatalf("NewPlaybackGame() error: %v", err)
+	}
+	if game.HighScores != nil || game.DailyHistory != nil || game.SaveFile != "" || game.SavedRun != nil {
+		t.Errorf("Expected a playback game without the player's files, got %v, %v, %q and %v",
+			game.HighScores, game.DailyHistory, game.SaveFile, game.SavedRun)
+	}
+}
@@ -0,0 +1,61 @@
+package core
+
+import "math/rand"
+
+// RandState is everything needed to restore a seededRand: the seed and how
+// many values were drawn since
+type RandState struct {
+	Seed  int64  `json:"seed"`
+	Draws uint64 `json:"draws"`
+}
+
+// seededRand is a math/rand generator that counts the values it draws, so its
+// state can be saved and restored
+type seededRand struct {
+	*rand.Rand
+	src *countingSource
+}
+
+// countingSource counts every value drawn from the underlying source
+type countingSource struct {
+	src   rand.Source64
+	state RandState
+}
+
+func (s *countingSource) Int63() int64 {
+	s.state.Draws++
+	return s.src.Int63()
+}
+
+func (s *countingSource) Uint64() uint64 {
+	s.state.Draws++
+	return s.src.Uint64()
+}
+
+func (s *countingSource) Seed(seed int64) {
+	s.src.Seed(seed)
+	s.state = RandState{Seed: seed}
+}
+
+// newSeededRand returns a generator seeded with seed
+func newSeededRand(seed int64) *seededRand {
+	src := &countingSource{
+		src:   rand.NewSource(seed).(rand.Source64),
+		state: RandState{Seed: seed},
+	}
+	return &seededRand{Rand: rand.New(src), src: src}
+}
+
+// restoreRand returns a generator in the given state
+func restoreRand(state RandState) *seededRand {
+	r := newSeededRand(state.Seed)
+	for i := uint64(0); i < state.Draws; i++ {
+		r.src.Uint64()
+	}
+	return r
+}
+
+// State returns the generator's current state
+func (r *seededRand) State() RandState {
+	return r.src.state
+}
@@ -0,0 +1,305 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	saveFile    = "savegame.json"
+	saveVersion = 1
+)
+
+// ErrIncompatibleSave is returned for saves this build can't resume
+var ErrIncompatibleSave = errors.New("incompatible save")
+
+// SaveGame is an in-progress run written to disk so it can be resumed later
+type SaveGame struct {
+	Version   int         `json:"version"`
+	Saved     time.Time   `json:"saved"` // wall time the run was saved
+	Settings  RunSettings `json:"settings"`
+	FixedSeed bool        `json:"fixed_seed"`
+	Tuning    Tuning      `json:"tuning"`
+	Errors    ErrorPolicy `json:"errors,omitempty"` // empty for runs saved before error policies
+	Accents   bool        `json:"ignore_accents,omitempty"`
+	Width     int         `json:"width"`
+	Height    int         `json:"height"`
+
+	Player    Player     `json:"player"`
+	Platforms []Platform `json:"platforms"`
+	Score     int        `json:"score"`
+	Words     int        `json:"words"`
+	Chars     int        `json:"chars"`
+	Mistakes  int        `json:"mistakes"`
+
+	StartTime  time.Time     `json:"start_time"`
+	PausedTime time.Duration `json:"paused_time"`
+	PausedAt   time.Time     `json:"paused_at"`
+	ActiveTime time.Duration `json:"active_time"`
+	Frame      int           `json:"frame"`
+
+	Timeline []TimelineSample `json:"timeline"`
+
+	ScrollSpeed       float64 `json:"scroll_speed"`
+	ScrollOffset      float64 `json:"scroll_offset"`
+	ScrollAccumulator float64 `json:"scroll_accumulator"`
+
+	Combo         int            `json:"combo"`
+	BestCombo     int            `json:"best_combo"`
+	Breakdown     ScoreBreakdown `json:"breakdown"`
+	Callout       string         `json:"callout"`
+	CalloutTimer  time.Duration  `json:"callout_timer"`
+	WordStartedAt time.Duration  `json:"word_started_at"`
+	WordMistakes  int            `json:"word_mistakes"`
+
+	StartingLives int           `json:"starting_lives"`
+	Lives         int           `json:"lives"`
+	Invulnerable  time.Duration `json:"invulnerable"`
+	PlatformCount int           `json:"platform_count"`
+	Effects       []Effect      `json:"effects"`
+	Shields       int           `json:"shields"`
+
+	Daily       bool   `json:"daily"`
+	DailyScored bool   `json:"daily_scored"`
+	DailyDate   string `json:"daily_date,omitempty"` // date of the scored attempt
+
+	Rand      RandState `json:"rand"`      // platform generator
+	WordRand  RandState `json:"word_rand"` // word generator
+	Recording *Replay   `json:"recording,omitempty"`
+}
+
+// LoadSaveGame reads a saved run. A missing file is not an error and yields nil.
+func LoadSaveGame(path string) (*SaveGame, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the version first, other fields may mean something else in other versions
+	var header struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrIncompatibleSave, err)
+	}
+	if header.Version != saveVersion {
+		return nil, fmt.Errorf("%w: version %d, expected %d", ErrIncompatibleSave, header.Version, saveVersion)
+	}
+
+	save := &SaveGame{}
+	if err := json.Unmarshal(data, save); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrIncompatibleSave, err)
+	}
+	if err := save.Settings.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrIncompatibleSave, err)
+	}
+	if err := save.Tuning.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrIncompatibleSave, err)
+	}
+	if save.Errors == "" {
+		save.Errors = ErrorsStop
+	}
+	if _, ok := ErrorPolicyByID(string(save.Errors)); !ok {
+		return nil, fmt.Errorf("%w: unknown error policy %q", ErrIncompatibleSave, save.Errors)
+	}
+	if len(save.Platforms) == 0 || save.Player.Platform >= len(save.Platforms) {
+		return nil, fmt.Errorf("%w: no platform to stand on", ErrIncompatibleSave)
+	}
+	return save, nil
+}
+
+// Save writes the saved run to path
+func (s *SaveGame) Save(path string) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// saveRun writes the paused run to SaveFile so it can be continued later.
+// It is called for the key that saves the run, which isn't part of the run.
+func (g *Game) saveRun() {
+	if g.SaveFile == "" {
+		return
+	}
+	g.saveKeyStats()
+	recording := g.recording
+	if recording != nil && len(recording.Events) > 0 {
+		recording.Events = recording.Events[:len(recording.Events)-1]
+	}
+	g.recording = nil // The run goes on in the save
+
+	save := &SaveGame{
+		Version:   saveVersion,
+		Saved:     g.now(),
+		Settings:  g.RunSettings(),
+		FixedSeed: g.FixedSeed,
+		Tuning:    g.Tuning,
+		Errors:    g.Errors,
+		Accents:   g.WordManager.IgnoreAccents,
+		Width:     g.Width,
+		Height:    g.Height,
+
+		Player:    g.Player,
+		Platforms: append([]Platform(nil), g.Platforms...),
+		Score:     g.Score,
+		Words:     g.WordsTyped,
+		Chars:     g.CharsTyped,
+		Mistakes:  g.Mistakes,
+
+		StartTime:  g.StartTime,
+		PausedTime: g.PausedTime,
+		PausedAt:   g.pausedAt,
+		ActiveTime: g.ActiveTime,
+		Frame:      g.frame,
+
+		Timeline: append([]TimelineSample(nil), g.Timeline...),
+
+		ScrollSpeed:       g.ScrollSpeed,
+		ScrollOffset:      g.ScrollOffset,
+		ScrollAccumulator: g.ScrollAccumulator,
+
+		Combo:         g.Combo,
+		BestCombo:     g.BestCombo,
+		Breakdown:     g.Breakdown,
+		Callout:       g.Callout,
+		CalloutTimer:  g.CalloutTimer,
+		WordStartedAt: g.wordStartedAt,
+		WordMistakes:  g.wordMistakes,
+
+		StartingLives: g.StartingLives,
+		Lives:         g.Lives,
+		Invulnerable:  g.Invulnerable,
+		PlatformCount: g.platformCount,
+		Effects:       append([]Effect(nil), g.Effects...),
+		Shields:       g.Shields,
+
+		Daily:       g.Daily,
+		DailyScored: g.DailyScored,
+		DailyDate:   g.DailyDate,
+
+		Rand:      g.rng.State(),
+		WordRand:  g.WordManager.rng.State(),
+		Recording: recording,
+	}
+	if err := save.Save(g.SaveFile); err != nil {
+		g.Logger.Printf("saveRun: failed to save: %v", err)
+		g.MenuMessage = g.tr("Failed to save the run: %s", err)
+		return
+	}
+	g.Logger.Printf("saveRun: saved to %s", g.SaveFile)
+	g.SavedRun = save
+}
+
+// resumeRun restores the saved run, paused, and removes the save so a run
+// can only be continued once
+func (g *Game) resumeRun() {
+	s := g.SavedRun
+	if s == nil {
+		return
+	}
+	// Settings.Validate passed when the save was loaded, but a layout its
+	// pack drills on may be gone since
+	mode, ok := ModeByID(s.Settings.Mode)
+	if !ok {
+		g.failResume(fmt.Errorf("%w: unknown mode %q", ErrIncompatibleSave, s.Settings.Mode))
+		return
+	}
+	pack, ok := WordPackByID(s.Settings.Pack)
+	if !ok {
+		g.failResume(fmt.Errorf("%w: unknown word pack %q", ErrIncompatibleSave, s.Settings.Pack))
+		return
+	}
+	g.SavedRun = nil
+	if err := os.Remove(g.SaveFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		g.Logger.Printf("resumeRun: failed to remove save: %v", err)
+	}
+	if s.Daily && !g.Daily {
+		g.keepMenuChoices()
+	}
+
+	g.Mode = mode
+	g.WordManager.SetPack(pack)
+	g.WordManager.SetDifficulty(s.Settings.Difficulty)
+	g.SetTuning(s.Tuning)
+	g.Errors = s.Errors
+	g.WordManager.IgnoreAccents = s.Accents
+	g.Seed = s.Settings.Seed
+	g.FixedSeed = s.FixedSeed
+	g.ClearGhost()
+	g.ghost = nil
+
+	g.Player = s.Player
+	g.Platforms = s.Platforms
+	g.Score = s.Score
+	g.WordsTyped = s.Words
+	g.CharsTyped = s.Chars
+	g.Mistakes = s.Mistakes
+
+	// Shift the wall clock by the time the run spent on disk
+	shift := g.now().Sub(s.Saved)
+	g.StartTime = s.StartTime.Add(shift)
+	g.PausedTime = s.PausedTime
+	g.pausedAt = s.PausedAt.Add(shift)
+	g.ActiveTime = s.ActiveTime
+	g.frame = s.Frame
+	g.Timeline = s.Timeline
+
+	g.ScrollSpeed = s.ScrollSpeed
+	g.ScrollOffset = s.ScrollOffset
+	g.ScrollAccumulator = s.ScrollAccumulator
+
+	g.Combo = s.Combo
+	g.BestCombo = s.BestCombo
+	g.Breakdown = s.Breakdown
+	g.Callout = s.Callout
+	g.CalloutTimer = s.CalloutTimer
+	g.wordStartedAt = s.WordStartedAt
+	g.wordMistakes = s.WordMistakes
+
+	g.StartingLives = s.StartingLives
+	g.Lives = s.Lives
+	g.Invulnerable = s.Invulnerable
+	g.platformCount = s.PlatformCount
+	g.Effects = s.Effects
+	g.Shields = s.Shields
+
+	g.Daily = s.Daily
+	g.DailyScored = s.DailyScored
+	g.DailyDate = s.DailyDate
+	if g.DailyScored && g.DailyDate == "" {
+		g.DailyDate = s.StartTime.UTC().Format(dateLayout) // Saved before the date was kept
+	}
+
+	g.rng = restoreRand(s.Rand)
+	g.WordManager.rng = restoreRand(s.WordRand)
+	g.recording = s.Recording
+	if g.recording != nil {
+		g.recording.Start = g.recording.Start.Add(shift)
+		if g.Width != s.Width || g.Height != s.Height {
+			// The terminal changed size while the run was on disk
+			g.recordEvent(ReplayEvent{Width: g.Width, Height: g.Height})
+		}
+	}
+
+	g.EndReason = ""
+	g.HighScoreRank = 0
+	g.DailyRecorded = false
+	g.State = StatePaused
+	g.Logger.Printf("resumeRun: resumed %s run with %d words", g.Mode.ID(), g.WordsTyped)
+}
+
+// failResume reports a saved run that can't be continued and drops it from
+// the menu, leaving the file in place
+func (g *Game) failResume(err error) {
+	g.Logger.Printf("resumeRun: %v", err)
+	g.SavedRun = nil
+	g.MenuMessage = g.tr("The saved run can't be continued: %s", err)
+}
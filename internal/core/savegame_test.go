@@ -0,0 +1,153 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRandStateRestore(t *testing.T) {
+	r := newSeededRand(5)
+	for i := 0; i < 17; i++ {
+		r.Intn(100)
+	}
+	restored := restoreRand(r.State())
+	for i := 0; i < 10; i++ {
+		if a, b := r.Intn(1000), restored.Intn(1000); a != b {
+			t.Fatalf("Expected draw %d to match, got %d and %d", i, a, b)
+		}
+	}
+}
+
+// playSome types a few words and lets the course scroll on
+func playSome(game *Game, words, frames int) {
+	for i := 0; i < words; i++ {
+		typeCurrentWord(game, i%2 == 0)
+	}
+	for i := 0; i < frames && game.State == StatePlaying; i++ {
+		game.Render()
+	}
+}
+
+func TestSaveAndResume(t *testing.T) {
+	settings := RunSettings{Mode: "zen", Pack: "common", Difficulty: 2, Seed: 77}
+	reference := seededGame(t, settings)
+	saved := seededGame(t, settings)
+	playSome(reference, 3, 100)
+	playSome(saved, 3, 100)
+
+	path := filepath.Join(t.TempDir(), "save.json")
+	saved.SaveFile = path
+	saved.ProcessInput(27)
+	saved.ProcessInput('s')
+	if saved.State != StateMenu || saved.SavedRun == nil {
+		t.Fatalf("Expected the run to be saved, state=%v", saved.State)
+	}
+
+	resumed := newPlayingGame(t)
+	resumed.State = StateMenu
+	resumed.SaveFile = path
+	save, err := LoadSaveGame(path)
+	if err != nil || save == nil {
+		t.Fatalf("LoadSaveGame() = %v, %v", save, err)
+	}
+	resumed.SavedRun = save
+	resumed.ProcessInput('\r')
+	if resumed.State != StatePaused {
+		t.Fatalf("Expected the resumed run to start paused, got %v", resumed.State)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Error("Expected the save to be removed once continued")
+	}
+	resumed.ProcessInput(27)
+	reference.ProcessInput(27)
+	reference.ProcessInput(27)
+
+	// The resumed run goes on exactly like the one that was never saved
+	playSome(reference, 5, 600)
+	playSome(resumed, 5, 600)
+	if resumed.Score != reference.Score || resumed.WordsTyped != reference.WordsTyped || resumed.Combo != reference.Combo {
+		t.Errorf("Expected score %d, %d words and combo %d, got %d, %d and %d",
+			reference.Score, reference.WordsTyped, reference.Combo, resumed.Score, resumed.WordsTyped, resumed.Combo)
+	}
+	if len(resumed.Platforms) != len(reference.Platforms) {
+		t.Fatalf("Expected %d platforms, got %d", len(reference.Platforms), len(resumed.Platforms))
+	}
+	for i, want := range reference.Platforms {
+		got := resumed.Platforms[i]
+		if got.Word != want.Word || got.X != want.X || got.Y != want.Y || got.Type != want.Type || got.Seq != want.Seq {
+			t.Errorf("Expected platform %d to be %+v, got %+v", i, want, got)
+		}
+	}
+}
+
+func TestLoadSaveGameErrors(t *testing.T) {
+	dir := t.TempDir()
+	if save, err := LoadSaveGame(filepath.Join(dir, "missing.json")); save != nil || err != nil {
+		t.Errorf("Expected no save and no error for a missing file, got %v, %v", save, err)
+	}
+
+	tests := map[string]string{
+		"old.json":     `{"version": 99, "score": "a string in version 99"}`,
+		"garbage.json": `not json`,
+		"empty.json":   `{"version": 1}`,
+	}
+	for name, content := range tests {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadSaveGame(path); !errors.Is(err, ErrIncompatibleSave) {
+			t.Errorf("%s: expected ErrIncompatibleSave, got %v", name, err)
+		}
+	}
+}
+
+func TestResumeUnknownPack(t *testing.T) {
+	game := newPlayingGame(t)
+	game.SaveFile = filepath.Join(t.TempDir(), "save.json")
+	game.ProcessInput(27)
+	game.ProcessInput('s')
+	if game.SavedRun == nil {
+		t.Fatalf("Expected the run to be saved (%s)", game.MenuMessage)
+	}
+
+	// A drill whose layout was removed since the run was saved
+	game.SavedRun.Settings.Pack = "drill:home:gone"
+	game.resumeRun()
+	if game.State != StateMenu || game.SavedRun != nil || game.MenuMessage == "" {
+		t.Errorf("Expected the save to be reported and dropped, got state %v (%q)", game.State, game.MenuMessage)
+	}
+	if _, err := os.Stat(game.SaveFile); err != nil {
+		t.Errorf("Expected the save file to be kept, got %v", err)
+	}
+}
+
+func TestResumedRunReplays(t *testing.T) {
+	game := seededGame(t, RunSettings{Mode: "survival", Pack: "common", Difficulty: 1, Seed: 3})
+	game.SaveFile = filepath.Join(t.TempDir(), "save.json")
+	playSome(game, 2, 30)
+	game.ProcessInput(27)
+	game.ProcessInput('s')
+
+	game.ProcessInput('\r')
+	game.ProcessInput(27)
+	playSome(game, 2, 30)
+	game.ProcessInput(27)
+	game.ProcessInput('\r')
+	if game.State != StateGameOver || game.LastReplay == nil {
+		t.Fatalf("Expected the resumed run to finish with a replay, state=%v", game.State)
+	}
+
+	replayed := newTestGame(t)
+	player, err := NewReplayPlayer(replayed, game.LastReplay)
+	if err != nil {
+		t.Fatalf("NewReplayPlayer() error: %v", err)
+	}
+	player.Start(80, 24)
+	player.PlayAll()
+	if got, want := replayed.GetStats(), game.GetStats(); got != want {
+		t.Errorf("Expected the replay of the resumed run to end with %+v, got %+v", want, got)
+	}
+}
@@ -0,0 +1,123 @@
+package core
+
+import (
+	"math"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	charParTime      = 400 * time.Millisecond // par time per character for the speed bonus
+	comboStep        = 0.1                    // multiplier added per clean word in a row
+	maxComboSteps    = 20                     // multiplier caps at 1 + maxComboSteps*comboStep
+	calloutDuration  = 2 * time.Second        // how long streak callouts stay on screen
+	milestoneBonusPt = 10                     // milestone bonus per word of the streak
+)
+
+// streakMilestones are the clean word streaks that earn a callout and a bonus
+var streakMilestones = []int{10, 25, 50, 100}
+
+// ScoreBreakdown splits a score into the parts of the scoring model
+type ScoreBreakdown struct {
+	Base      int `json:"base"`      // 10 points per character
+	Speed     int `json:"speed"`     // bonus for beating the per-word par time
+	Combo     int `json:"combo"`     // extra points from the combo multiplier
+	Milestone int `json:"milestone"` // bonuses for reaching streak milestones
+	PowerUp   int `json:"powerup"`   // extra points from the double points power-up
+}
+
+// Total returns the sum of all parts
+func (b ScoreBreakdown) Total() int {
+	return b.Base + b.Speed + b.Combo + b.Milestone + b.PowerUp
+}
+
+// Add accumulates another breakdown into b
+func (b *ScoreBreakdown) Add(other ScoreBreakdown) {
+	b.Base += other.Base
+	b.Speed += other.Speed
+	b.Combo += other.Combo
+	b.Milestone += other.Milestone
+	b.PowerUp += other.PowerUp
+}
+
+// ComboMultiplier returns the score multiplier for the current combo
+func (g *Game) ComboMultiplier() float64 {
+	return 1 + math.Min(float64(g.Combo), maxComboSteps)*comboStep
+}
+
+// activateWord marks the player's current platform word as the one being typed.
+// It is called whenever the player lands on a platform.
+func (g *Game) activateWord() {
+	g.wordStartedAt = g.ActiveTime
+	g.wordMistakes = 0
+	if len(g.Platforms) > 0 {
+		g.Platforms[g.Player.Platform].Land(g)
+	}
+}
+
+// registerMistake breaks the combo after a wrong key
+func (g *Game) registerMistake() {
+	g.Mistakes++
+	g.wordMistakes++
+	g.Combo = 0
+}
+
+// registerWord updates the combo after a completed word and shows a
+// callout when the streak reaches a milestone
+func (g *Game) registerWord() {
+	if g.wordMistakes > 0 {
+		g.Combo = 0
+		return
+	}
+	g.Combo++
+	if g.Combo > g.BestCombo {
+		g.BestCombo = g.Combo
+	}
+	if isMilestone(g.Combo) {
+		g.showCallout(g.tr("%d WORD STREAK!", g.Combo))
+	}
+}
+
+// isMilestone reports whether a streak length is one of the streak milestones
+func isMilestone(streak int) bool {
+	for _, milestone := range streakMilestones {
+		if streak == milestone {
+			return true
+		}
+	}
+	return false
+}
+
+// showCallout displays a short message over the playing field
+func (g *Game) showCallout(text string) {
+	g.Callout = text
+	g.CalloutTimer = calloutDuration
+}
+
+// standardWordScore scores a completed word: base points for every character
+// typed right, a bonus for typing it faster than par since it became active,
+// the combo multiplier on top and a bonus when the word completes a streak
+// milestone. Wrong characters only remain in words completed under ErrorsSkip.
+func standardWordScore(g *Game, platform *Platform) ScoreBreakdown {
+	var b ScoreBreakdown
+	length := utf8.RuneCountInString(platform.Word)
+	right := length
+	for _, wrong := range g.WordManager.typedErrors(platform.Word, platform.Typed) {
+		if wrong {
+			right--
+		}
+	}
+	b.Base = right * 10
+
+	par := time.Duration(length) * charParTime
+	wordTime := g.ActiveTime - g.wordStartedAt
+	if wordTime < par {
+		b.Speed = int(float64(b.Base) * (1 - float64(wordTime)/float64(par)))
+	}
+
+	b.Combo = int(float64(b.Base+b.Speed) * (g.ComboMultiplier() - 1))
+	if isMilestone(g.Combo) {
+		b.Milestone = g.Combo * milestoneBonusPt
+	}
+	return b
+}
@@ -0,0 +1,91 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+// typeCurrentWord types the player's current word, optionally with one wrong key first
+func typeCurrentWord(game *Game, withMistake bool) {
+	platform := &game.Platforms[game.Player.Platform]
+	if withMistake {
+		game.ProcessInput('9') // Default words never contain digits
+	}
+	for _, ch := range platform.Word {
+		game.ProcessInput(ch)
+	}
+}
+
+func TestComboBuildsAndResets(t *testing.T) {
+	game := newPlayingGame(t)
+
+	typeCurrentWord(game, false)
+	typeCurrentWord(game, false)
+	if game.Combo != 2 {
+		t.Fatalf("Expected combo 2 after two clean words, got %d", game.Combo)
+	}
+	if m := game.ComboMultiplier(); m <= 1 {
+		t.Errorf("Expected multiplier above 1 with a combo, got %.2f", m)
+	}
+
+	game.ProcessInput('9')
+	if game.Combo != 0 {
+		t.Errorf("Expected mistake to reset combo, got %d", game.Combo)
+	}
+	if game.BestCombo != 2 {
+		t.Errorf("Expected best combo 2, got %d", game.BestCombo)
+	}
+
+	typeCurrentWord(game, false)
+	if game.Combo != 0 {
+		t.Errorf("Expected word with a mistake not to count towards the combo, got %d", game.Combo)
+	}
+}
+
+func TestSpeedBonusUsesWordTime(t *testing.T) {
+	game := newPlayingGame(t)
+	platform := &Platform{Word: "hello"}
+
+	game.activateWord()
+	fast := standardWordScore(game, platform)
+
+	game.ActiveTime += 10 * time.Second
+	slow := standardWordScore(game, platform)
+
+	if fast.Speed <= 0 {
+		t.Errorf("Expected a speed bonus for an instant word, got %d", fast.Speed)
+	}
+	if slow.Speed != 0 {
+		t.Errorf("Expected no speed bonus for a slow word, got %d", slow.Speed)
+	}
+	if fast.Base != 50 || slow.Base != 50 {
+		t.Errorf("Expected base score 50, got %d and %d", fast.Base, slow.Base)
+	}
+}
+
+func TestStreakMilestoneCallout(t *testing.T) {
+	game := newPlayingGame(t)
+	game.Combo = streakMilestones[0] - 1
+
+	platform := &Platform{Word: "hello"}
+	game.registerWord()
+	breakdown := standardWordScore(game, platform)
+
+	if game.CalloutTimer <= 0 || game.Callout == "" {
+		t.Error("Expected a callout when reaching a streak milestone")
+	}
+	if breakdown.Milestone == 0 {
+		t.Error("Expected a milestone bonus in the breakdown")
+	}
+}
+
+func TestScoreMatchesBreakdown(t *testing.T) {
+	game := newPlayingGame(t)
+
+	for i := 0; i < 3; i++ {
+		typeCurrentWord(game, i == 1)
+	}
+	if game.Score != game.Breakdown.Total() {
+		t.Errorf("Expected score %d to equal breakdown total %d", game.Score, game.Breakdown.Total())
+	}
+}
@@ -0,0 +1,144 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// newSettingsMenu creates the settings screen. Changes apply at once and are
+// saved to the active profile and the config file.
+func (g *Game) newSettingsMenu() *Menu {
+	menu := &Menu{
+		Items: []MenuItem{
+			{Label: "Difficulty", Value: func() string { return g.Preset().Name }, Run: func(step int) {
+				g.SetDifficulty(cycle(len(presets), g.WordManager.Difficulty-1, step) + 1)
+				g.settingChanged()
+			}},
+			{Label: "Mode", Value: func() string { return g.Mode.Name() }, Run: func(step int) {
+				i := slices.IndexFunc(gameModes, func(m GameMode) bool { return m.ID() == g.Mode.ID() })
+				g.Mode = gameModes[cycle(len(gameModes), i, step)]
+				g.settingChanged()
+			}},
+			{Label: "Word pack", Value: func() string { return g.WordManager.PackName }, Run: func(step int) {
+				i := slices.IndexFunc(wordPacks, func(p *WordPack) bool { return p.ID == g.WordManager.Pack })
+				g.WordManager.SetPack(wordPacks[cycle(len(wordPacks), i, step)])
+				g.settingChanged()
+			}},
+			{Label: "Lives", Value: func() string { return livesName(g.StartingLives) }, Run: func(step int) {
+				i := slices.Index(livesOptions, g.StartingLives)
+				g.StartingLives = livesOptions[cycle(len(livesOptions), i, step)]
+				g.settingChanged()
+			}},
+			{Label: "Theme", Value: func() string { return g.Theme.Name }, Run: func(step int) {
+				g.Theme = themes[cycle(len(themes), slices.Index(themes, g.Theme), step)]
+				g.settingChanged()
+			}},
+			{Label: "Language", Value: func() string { return g.Locale.Name }, Run: func(step int) {
+				g.Locale = locales[cycle(len(locales), slices.Index(locales, g.Locale), step)]
+				g.settingChanged()
+			}},
+			{Label: "Layout", Value: func() string { return g.Layout.Name }, Run: func(step int) {
+				g.setLayout(layouts[cycle(len(layouts), slices.Index(layouts, g.Layout), step)])
+			}},
+			{Label: "Errors", Value: func() string { return g.Errors.Name() }, Run: func(step int) {
+				g.Errors = errorPolicies[cycle(len(errorPolicies), slices.Index(errorPolicies, g.Errors), step)]
+				g.settingChanged()
+			}},
+			{Label: "Accents", Value: func() string { return accentsName(g.WordManager.IgnoreAccents) }, Run: func(int) {
+				g.WordManager.IgnoreAccents = !g.WordManager.IgnoreAccents
+				g.settingChanged()
+			}},
+		},
+		Back: func() { g.State = StateMenu },
+	}
+	for _, info := range actions {
+		action := info.Action
+		menu.Items = append(menu.Items, MenuItem{
+			Label: "Key: " + info.Name,
+			Value: func() string { return g.Bindings.Name(action) },
+			Run:   func(int) { g.bindingCapture = action },
+		})
+	}
+	menu.Items = append(menu.Items,
+		MenuItem{Label: "Reset keys", Run: func(int) { g.rebind(DefaultBindings()) }},
+		MenuItem{Label: "Back", Shortcut: 'q', Run: func(int) { g.State = StateMenu }},
+	)
+	return menu
+}
+
+// processSettingsInput handles the settings screen. After a key binding is
+// picked the next key, whichever it is, is bound to its action.
+func (g *Game) processSettingsInput(key rune) {
+	g.Logger.Debugf("processSettingsInput: key=%v", key)
+	g.MenuMessage = ""
+	if g.bindingCapture != "" {
+		action := g.bindingCapture
+		g.bindingCapture = ""
+		bindings := g.Bindings.With(action, unicode.ToLower(key))
+		if err := bindings.Validate(); err != nil {
+			g.MenuMessage = strings.TrimPrefix(err.Error(), ErrInvalidConfig.Error()+": ")
+			return
+		}
+		g.rebind(bindings)
+		return
+	}
+	g.settingsMenu.ProcessInput(key)
+}
+
+// rebind switches to new key bindings and saves them to the config file
+func (g *Game) rebind(bindings Bindings) {
+	g.SetBindings(bindings)
+	if g.Config == nil {
+		return
+	}
+	g.Config.Bindings = bindings.Names()
+	if err := g.Config.Save(); err != nil {
+		g.Logger.Printf("rebind: failed to save config: %v", err)
+		g.MenuMessage = g.tr("Key bindings not saved: %s", err)
+	}
+}
+
+// settingChanged saves the settings after a change on the settings screen.
+// The run settings go to the active profile and, like the theme, the
+// language, the error policy and the accent matching, to the config file so
+// they are also used on the next start.
+func (g *Game) settingChanged() {
+	g.ClearGhost()
+	g.saveProfileSettings()
+	c := g.Config
+	if c == nil {
+		return
+	}
+	c.Mode = g.Mode.ID()
+	c.Pack = g.WordManager.Pack
+	c.Difficulty = g.Preset().ID
+	c.Theme = g.Theme.ID
+	c.Locale = g.Locale.ID
+	c.Errors = string(g.Errors)
+	c.Accents = AccentsExact
+	if g.WordManager.IgnoreAccents {
+		c.Accents = AccentsIgnore
+	}
+	if err := c.Save(); err != nil {
+		g.Logger.Printf("settingChanged: failed to save config: %v", err)
+		g.MenuMessage = g.tr("Settings not saved: %s", err)
+	}
+}
+
+// accentsName returns how the accent matching is shown
+func accentsName(ignore bool) string {
+	if ignore {
+		return "Ignored"
+	}
+	return "Exact"
+}
+
+// livesName returns how a lives option is shown
+func livesName(lives int) string {
+	if lives == 0 {
+		return "off"
+	}
+	return fmt.Sprintf("%d", lives)
+}
@@ -0,0 +1,48 @@
+package core
+
+import "strings"
+
+// ColorDefault is the terminal's own foreground color
+const ColorDefault = "\033[39m"
+
+// Theme recolors rendered frames by swapping the renderer's color codes
+type Theme struct {
+	ID       string
+	Name     string
+	replacer *strings.Replacer // nil keeps the colors as they are
+}
+
+// themes holds every theme in menu order; the first one is the default
+var themes = []*Theme{
+	{ID: "classic", Name: "Classic"},
+	{ID: "mono", Name: "Monochrome", replacer: strings.NewReplacer(
+		ColorRed, "", ColorGreen, "", ColorYellow, "", ColorBlue, "",
+		ColorPurple, "", ColorCyan, "", ColorWhite, "",
+	)},
+	{ID: "light", Name: "Light background", replacer: strings.NewReplacer(
+		ColorWhite, ColorDefault, ColorYellow, ColorBlue, ColorCyan, ColorBlue,
+	)},
+}
+
+// Themes returns every theme in menu order
+func Themes() []*Theme {
+	return themes
+}
+
+// ThemeByID looks up a theme by its ID
+func ThemeByID(id string) (*Theme, bool) {
+	for _, theme := range themes {
+		if theme.ID == id {
+			return theme, true
+		}
+	}
+	return nil, false
+}
+
+// apply recolors a rendered frame
+func (t *Theme) apply(frame string) string {
+	if t == nil || t.replacer == nil {
+		return frame
+	}
+	return t.replacer.Replace(frame)
+}
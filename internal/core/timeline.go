@@ -0,0 +1,94 @@
+package core
+
+import "time"
+
+const (
+	timelineInterval = time.Second // game time between samples
+	timelineWindow   = 10          // samples WPM and accuracy are averaged over
+	clusterWindow    = 5           // samples a mistake cluster is counted over
+	clusterMistakes  = 3           // mistakes within clusterWindow that make a cluster
+)
+
+// TimelineSample is the state of the run at a point in game time. Counters
+// are totals since the start of the run.
+type TimelineSample struct {
+	Time     time.Duration `json:"time"` // ActiveTime of the sample
+	Words    int           `json:"words"`
+	Chars    int           `json:"chars"`
+	Mistakes int           `json:"mistakes"`
+	Speed    float64       `json:"speed"` // scroll speed
+}
+
+// sampleTimeline adds a sample every timelineInterval of game time
+func (g *Game) sampleTimeline() {
+	next := timelineInterval * time.Duration(len(g.Timeline)+1)
+	if g.ActiveTime >= next {
+		g.addSample()
+	}
+}
+
+// addSample records the current state of the run
+func (g *Game) addSample() {
+	g.Timeline = append(g.Timeline, TimelineSample{
+		Time:     g.ActiveTime,
+		Words:    g.WordsTyped,
+		Chars:    g.CharsTyped,
+		Mistakes: g.Mistakes,
+		Speed:    g.ScrollSpeed,
+	})
+}
+
+// finishTimeline records the final state of a run that just ended
+func (g *Game) finishTimeline() {
+	if n := len(g.Timeline); n == 0 || g.Timeline[n-1].Time < g.ActiveTime {
+		g.addSample()
+	}
+}
+
+// TimelineSeries are the charted series of a run, one value per sample
+type TimelineSeries struct {
+	WPM      []float64 // over the last timelineWindow samples
+	Accuracy []float64 // over the last timelineWindow samples
+	Speed    []float64
+	Ramps    []bool // the scroll speed went up since the previous sample
+	Clusters []bool // the sample ends a burst of mistakes
+}
+
+// Series derives the charted series from the samples
+func Series(samples []TimelineSample) TimelineSeries {
+	n := len(samples)
+	s := TimelineSeries{
+		WPM:      make([]float64, n),
+		Accuracy: make([]float64, n),
+		Speed:    make([]float64, n),
+		Ramps:    make([]bool, n),
+		Clusters: make([]bool, n),
+	}
+	for i, sample := range samples {
+		var base TimelineSample // The start of the run
+		if i >= timelineWindow {
+			base = samples[i-timelineWindow]
+		}
+		if minutes := (sample.Time - base.Time).Minutes(); minutes > 0 {
+			s.WPM[i] = float64(sample.Words-base.Words) / minutes
+		}
+		s.Accuracy[i] = 100
+		chars, mistakes := sample.Chars-base.Chars, sample.Mistakes-base.Mistakes
+		if chars+mistakes > 0 {
+			s.Accuracy[i] = float64(chars) / float64(chars+mistakes) * 100
+		}
+		s.Speed[i] = sample.Speed
+
+		var previous TimelineSample
+		if i > 0 {
+			previous = samples[i-1]
+			s.Ramps[i] = sample.Speed > previous.Speed
+		}
+		var cluster TimelineSample
+		if i >= clusterWindow {
+			cluster = samples[i-clusterWindow]
+		}
+		s.Clusters[i] = sample.Mistakes > previous.Mistakes && sample.Mistakes-cluster.Mistakes >= clusterMistakes
+	}
+	return s
+}
@@ -0,0 +1,84 @@
+package core
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// renderFrames advances the game by n frames
+func renderFrames(game *Game, n int) {
+	for i := 0; i < n; i++ {
+		game.Render()
+	}
+}
+
+func TestTimelineSamplesRun(t *testing.T) {
+	// No scrolling, the player can't fall, and a course without a freeze power-up
+	game := newPlayingGame(t, func(g *Game) {
+		g.ApplySettings(RunSettings{Mode: "zen", Pack: "classic", Difficulty: DifficultyEasy, Seed: 7})
+	})
+
+	renderFrames(game, 2*framesPerSecond+1) // Frames are a hair under 1/60s
+	for i := 0; i < game.Tuning.Speed.Interval; i++ {
+		typeCurrentWord(game, false)
+	}
+	renderFrames(game, framesPerSecond)
+	for i := 0; i < clusterMistakes; i++ {
+		game.ProcessInput('9')
+	}
+	renderFrames(game, framesPerSecond)
+	game.ProcessInput(27)
+	game.ProcessInput('\r')
+
+	if len(game.Timeline) != 4 {
+		t.Fatalf("Expected a sample per second, got %d", len(game.Timeline))
+	}
+	for i, sample := range game.Timeline {
+		if want := time.Duration(i+1) * time.Second; sample.Time < want || sample.Time > want+time.Second/framesPerSecond {
+			t.Errorf("Sample %d: expected it at %v, got %v", i, want, sample.Time)
+		}
+	}
+
+	series := Series(game.Timeline)
+	if !series.Ramps[2] || series.Ramps[1] || series.Ramps[3] {
+		t.Errorf("Expected the speed ramp in the third second only, got %v", series.Ramps)
+	}
+	if !series.Clusters[3] || series.Clusters[2] {
+		t.Errorf("Expected the mistake cluster in the last second only, got %v", series.Clusters)
+	}
+	if series.WPM[1] != 0 || series.WPM[2] <= 0 {
+		t.Errorf("Expected WPM to rise once words were typed, got %v", series.WPM)
+	}
+	if series.Accuracy[3] >= 100 {
+		t.Errorf("Expected the mistakes to lower accuracy, got %v", series.Accuracy)
+	}
+
+	game.ProcessInput('t')
+	screen := game.Render()
+	for _, want := range []string{"RUN TIMELINE", "speed ramp (1)", "mistake cluster (1)"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected the timeline to show %q", want)
+		}
+	}
+	game.ProcessInput('t')
+	if !strings.Contains(game.Render(), "GAME OVER") {
+		t.Error("Expected T to switch back to the results")
+	}
+}
+
+func TestPlot(t *testing.T) {
+	lines := plot([]float64{0, 5, 10}, 3, 10)
+	want := []string{"  *", " * ", "*  "}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("Row %d: expected %q, got %q", i, want[i], lines[i])
+		}
+	}
+	if got := resample([]float64{1, 3, 5, 7}, 2); got[0] != 2 || got[1] != 6 {
+		t.Errorf("Expected bucket averages [2 6], got %v", got)
+	}
+	if got := resampleMarks([]bool{false, true, false, false}, 2); !got[0] || got[1] {
+		t.Errorf("Expected only the first bucket marked, got %v", got)
+	}
+}
@@ -1,6 +1,8 @@
 package core
 
-import "time"
+import (
+	"time"
+)
 
 // GameInterface defines the public interface for the game engine
 type GameInterface interface {
@@ -19,6 +21,12 @@ const (
 	StatePlaying
 	StatePaused
 	StateGameOver
+	StateDailyHistory
+	StateProfiles
+	StateProgress
+	StateSettings
+	StateKeyboard
+	StateLessons
 )
 
 // Player represents the player character
@@ -29,11 +37,20 @@ type Player struct {
 
 // Platform represents a platform in the game
 type Platform struct {
-	X, Y     int
-	Width    int
-	Word     string
-	Typed    string
-	Complete bool
+	Seq        int // position in the course, the same for every run of a seed
+	X, Y       int
+	Width      int
+	Word       string
+	Typed      string
+	Complete   bool
+	Checkpoint bool    // completing it restores a life
+	PowerUp    PowerUp // effect granted when its word is completed
+
+	Type      PlatformType  // behaviour of the platform, see platformKinds
+	Direction int           // horizontal direction of moving platforms, -1 or 1
+	Timer     time.Duration // per-type timer: move step, crumble delay or melt window
+	Triggered bool          // the crumble or melt countdown is running
+	Collapsed bool          // crumbled or melted away, can't be stood on
 }
 
 // Game holds the game state and logic
@@ -45,8 +62,16 @@ type Game struct {
 	Platforms         []Platform
 	Score             int
 	StartTime         time.Time
+	ActiveTime        time.Duration // time spent in StatePlaying, advanced per frame
+	PausedTime        time.Duration // wall time spent paused, excluded from stats
+	pausedAt          time.Time
+	endedAt           time.Time // wall time the last run ended, freezes its stats
+	Clock             Clock     // source of wall time, swapped out by replays
+	callTime          time.Time // time of the input or frame being processed, see now
+	frame             int       // frames rendered since the run started
 	WordsTyped        int
 	CharsTyped        int
+	Mistakes          int
 	ShouldExit        bool
 	ScrollSpeed       float64
 	ScrollOffset      float64
@@ -54,6 +79,98 @@ type Game struct {
 	WordManager       *WordManager
 	Renderer          *Renderer
 	Logger            *Logger // Add a Logger field for debug logging
+	rng               *seededRand
+	Config            *Config     // configuration the game was created from, nil for defaults
+	Tuning            Tuning      // speed curve, word lengths and platform spacing of the difficulty
+	Theme             *Theme      // colors of rendered frames
+	Locale            *Locale     // language of the texts on screen
+	Layout            *Layout     // keyboard layout of the active profile
+	Bindings          Bindings    // keys of the remappable actions
+	Errors            ErrorPolicy // what wrong keys do to the typed word
+
+	// Run settings and challenge codes
+	Seed        int64  // seed of the current run
+	FixedSeed   bool   // keep Seed for every run instead of drawing a new one
+	MenuMessage string // feedback shown in the menu, e.g. challenge code errors
+	codeEntry   bool   // the menu is reading a challenge code
+	codeInput   string
+
+	// Menus
+	mainMenu       *Menu
+	settingsMenu   *Menu
+	lessonsMenu    *Menu
+	profilesMenu   *Menu
+	bindingCapture Action // action the settings screen binds the next key to
+
+	// Replays
+	ReplayDir      string  // directory finished runs are saved to, empty to not save them
+	LastReplay     *Replay // recording of the last finished run
+	LastReplayPath string  // file LastReplay was saved to, empty if it wasn't
+	recording      *Replay // the run being recorded
+
+	// Ghost racing
+	Ghost     *Replay       // run raced against, nil for no ghost
+	GhostName string        // who the ghost is, e.g. "personal best"
+	ghost     *ReplayPlayer // the ghost of the current run
+
+	// Saved runs
+	SaveFile string    // file paused runs are saved to, empty to not save them
+	SavedRun *SaveGame // run that can be continued from the menu, nil if none
+
+	// Daily challenge
+	Daily         bool   // the current run is the daily challenge
+	DailyScored   bool   // the current daily attempt counts, false for practice retries
+	DailyRecorded bool   // the last run was recorded as the day's result
+	DailyDate     string // UTC date the scored attempt started on, YYYY-MM-DD
+	DailyHistory  *DailyHistory
+	menuChoices   menuChoices // choices made before the daily, restored after it
+
+	// Lessons
+	Lessons      *LessonProgress // lesson progress of the active profile
+	LessonResult *LessonResult   // grade of the lesson run that just ended, nil if it wasn't one
+
+	// Profiles
+	Profiles      *ProfileStore // nil when profiles aren't used
+	Profile       *Profile      // the active profile
+	KeyStats      *KeyStats     // per-key analytics of the active profile
+	lastKeyAt     time.Duration // ActiveTime of the last correct key
+	profileAction string        // what the profile screen is reading input for
+	profileInput  string
+
+	// Session history and the progress screen
+	History        *SessionHistory
+	progressMode   string // mode charted, empty for all modes
+	progressCount  int    // index into progressCounts
+	progressMetric int    // index into progressMetrics, charted by day
+	// Mode and high scores
+	Mode          GameMode
+	EndReason     string // why the last run ended, shown on the game over screen
+	HighScores    *HighScoreTable
+	HighScoreRank int // rank of the last run in its mode's table, 0 if unranked
+
+	// Run timeline
+	Timeline     []TimelineSample // samples of the current or last run
+	showTimeline bool             // the game over screen shows the timeline chart
+
+	// Scoring
+	Combo         int // consecutive words completed without a mistake
+	BestCombo     int
+	Breakdown     ScoreBreakdown // how the score was earned this run
+	Callout       string         // short message shown over the playing field
+	CalloutTimer  time.Duration  // remaining time the callout stays visible
+	ErrorFlash    time.Duration  // remaining time the current word flashes after a mistake
+	wordStartedAt time.Duration  // ActiveTime when the current word became active
+	wordMistakes  int            // mistakes made on the current word
+
+	// Lives
+	StartingLives int           // lives per run, 0 disables the lives system
+	Lives         int           // lives left in the current run
+	Invulnerable  time.Duration // remaining invulnerability after a respawn
+	platformCount int           // platforms generated this run
+
+	// Power-ups
+	Effects []Effect // active timed power-ups
+	Shields int      // falls the player is protected from
 }
 
 // Stats represents game statistics
@@ -0,0 +1,186 @@
+package core
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	minKeyInterval   = 10 * time.Millisecond // fastest gap between two key presses a human manages
+	maxKeysPerSecond = 30                    // fastest sustained typing over a whole second
+	minFrameRate     = 40                    // frames per wall second below which the game was slowed down
+)
+
+// Verification is the outcome of re-simulating a replay
+type Verification struct {
+	Claimed  Stats    // stats stored in the replay
+	Replayed Stats    // stats the engine arrived at
+	Issues   []string // everything that doesn't add up, empty for a trustworthy run
+}
+
+// OK reports whether the replay checks out
+func (v *Verification) OK() bool {
+	return len(v.Issues) == 0
+}
+
+func (v *Verification) flag(format string, args ...interface{}) {
+	v.Issues = append(v.Issues, fmt.Sprintf(format, args...))
+}
+
+// VerifyReplay plays a replay headlessly through the engine and checks that
+// it ends with the stats it claims, and that its inputs could come from a human
+func VerifyReplay(replay *Replay) (*Verification, error) {
+	if err := replay.Validate(); err != nil {
+		return nil, err
+	}
+	v := &Verification{Claimed: replay.Stats}
+	v.checkInputs(replay)
+
+	player, err := NewReplayPlayer(newGame(newDiscardLogger()), replay)
+	if err != nil {
+		return nil, err
+	}
+	player.Start(replay.Width, replay.Height)
+	player.PlayAll()
+
+	game := player.Game()
+	if game.State != StateGameOver {
+		v.flag("the run doesn't end on frame %d as recorded", replay.EndFrame)
+		return v, nil
+	}
+	if game.frame != replay.EndFrame {
+		v.flag("the run ends on frame %d, recorded as %d", game.frame, replay.EndFrame)
+	}
+	if game.EndReason != replay.Reason {
+		v.flag("the run ends with %q, recorded as %q", game.EndReason, replay.Reason)
+	}
+	v.Replayed = game.GetStats()
+	v.compareStats()
+	return v, nil
+}
+
+// VerifyHighScore checks an entry of the high score table of the mode modeID
+// against the replay it points to: the replay must play that mode with the
+// entry's difficulty preset and arrive at the entry's stats
+func VerifyHighScore(modeID string, entry HighScore) (*Verification, error) {
+	if entry.Replay == "" {
+		return nil, fmt.Errorf("%w: the entry has no replay", ErrInvalidReplay)
+	}
+	replay, err := LoadReplay(entry.Replay)
+	if err != nil {
+		return nil, err
+	}
+	v, err := VerifyReplay(replay)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Score != v.Replayed.Score {
+		v.flag("the entry claims a score of %d, the replay scores %d", entry.Score, v.Replayed.Score)
+	}
+	if entry.Words != v.Replayed.WordsTyped {
+		v.flag("the entry claims %d words, the replay types %d", entry.Words, v.Replayed.WordsTyped)
+	}
+	if entry.WPM != v.Replayed.WPM {
+		v.flag("the entry claims %.2f WPM, the replay types %.2f", entry.WPM, v.Replayed.WPM)
+	}
+	if entry.Accuracy != v.Replayed.Accuracy {
+		v.flag("the entry claims %.2f%% accuracy, the replay types %.2f%%", entry.Accuracy, v.Replayed.Accuracy)
+	}
+	if replay.Settings.Mode != modeID {
+		v.flag("the entry is in the %s table, the replay plays %s", modeID, replay.Settings.Mode)
+	}
+	v.checkPreset(entry.Preset, replay)
+	return v, nil
+}
+
+// checkPreset flags a replay that wasn't played with the difficulty preset
+// of the entry
+func (v *Verification) checkPreset(id string, replay *Replay) {
+	preset, ok := PresetByID(id)
+	if !ok {
+		v.flag("the entry has the unknown difficulty preset %q", id)
+		return
+	}
+	if level := presetLevel(preset); replay.Settings.Difficulty != level {
+		v.flag("the entry is on %s, the replay plays %s", preset.Name, presetName(replay.Settings.Difficulty))
+		return
+	}
+	if preset.ID == "custom" {
+		// Custom is tuned by the config file of the player, within its limits
+		if err := replay.Tuning.Validate(); err != nil {
+			v.flag("the replay's Custom tuning isn't allowed: %v", err)
+		}
+	} else if replay.Tuning != preset.Tuning {
+		v.flag("the replay isn't tuned like %s", preset.Name)
+	}
+}
+
+// checkInputs flags input timing no human could produce
+func (v *Verification) checkInputs(replay *Replay) {
+	var keys []time.Duration
+	previous := time.Duration(0)
+	for _, event := range replay.Events {
+		if event.Time < previous {
+			v.flag("event at frame %d goes back in time", event.Frame)
+		}
+		previous = event.Time
+		if !event.IsResize() {
+			keys = append(keys, event.Time)
+		}
+	}
+	if replay.End < previous {
+		v.flag("the run ends before its last event")
+	}
+
+	tooFast := 0
+	for i := 1; i < len(keys); i++ {
+		if keys[i]-keys[i-1] < minKeyInterval {
+			tooFast++
+		}
+	}
+	if tooFast > 0 {
+		v.flag("%d key presses less than %v apart", tooFast, minKeyInterval)
+	}
+	for start, end := 0, 0; end < len(keys); end++ {
+		for keys[end]-keys[start] >= time.Second {
+			start++
+		}
+		if end-start+1 > maxKeysPerSecond {
+			v.flag("more than %d key presses within a second at %v", maxKeysPerSecond, keys[start].Round(time.Millisecond))
+			break
+		}
+	}
+
+	if replay.End > 0 {
+		fps := float64(replay.EndFrame) / replay.End.Seconds()
+		if fps < minFrameRate {
+			v.flag("the game ran at %.0f frames per second, slower than real time", fps)
+		}
+	}
+}
+
+// compareStats flags every stat that differs between the claim and the replay
+func (v *Verification) compareStats() {
+	c, r := v.Claimed, v.Replayed
+	if c.Score != r.Score {
+		v.flag("claimed score %d, replayed %d", c.Score, r.Score)
+	}
+	if c.WordsTyped != r.WordsTyped {
+		v.flag("claimed %d words, replayed %d", c.WordsTyped, r.WordsTyped)
+	}
+	if c.CharsTyped != r.CharsTyped {
+		v.flag("claimed %d characters, replayed %d", c.CharsTyped, r.CharsTyped)
+	}
+	if c.WPM != r.WPM {
+		v.flag("claimed %.2f WPM, replayed %.2f", c.WPM, r.WPM)
+	}
+	if c.CPM != r.CPM {
+		v.flag("claimed %.2f CPM, replayed %.2f", c.CPM, r.CPM)
+	}
+	if c.Accuracy != r.Accuracy {
+		v.flag("claimed %.2f%% accuracy, replayed %.2f%%", c.Accuracy, r.Accuracy)
+	}
+	if c.GameTime != r.GameTime {
+		v.flag("claimed a time of %v, replayed %v", c.GameTime, r.GameTime)
+	}
+}
@@ -0,0 +1,149 @@
+package core
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// copyReplay returns a deep copy that a test can tamper with
+func copyReplay(replay *Replay) *Replay {
+	tampered := *replay
+	tampered.Events = append([]ReplayEvent(nil), replay.Events...)
+	return &tampered
+}
+
+func TestVerifyReplay(t *testing.T) {
+	v, err := VerifyReplay(recordedReplay(t))
+	if err != nil {
+		t.Fatalf("VerifyReplay() error: %v", err)
+	}
+	if !v.OK() {
+		t.Errorf("Expected an honest replay to verify, got %v", v.Issues)
+	}
+	if v.Replayed != v.Claimed {
+		t.Errorf("Expected replayed stats %+v, got %+v", v.Claimed, v.Replayed)
+	}
+}
+
+func TestVerifyReplayFlagsTampering(t *testing.T) {
+	honest := recordedReplay(t)
+
+	tests := []struct {
+		name   string
+		tamper func(r *Replay)
+		issue  string
+	}{
+		{"inflated score", func(r *Replay) { r.Stats.Score += 500 }, "claimed score"},
+		{"edited keys", func(r *Replay) {
+			for i := range r.Events {
+				if r.Events[i].Key >= 'a' && r.Events[i].Key <= 'z' {
+					r.Events[i].Key = '#'
+				}
+			}
+		}, "claimed"},
+		{"superhuman typing", func(r *Replay) {
+			for i := range r.Events {
+				r.Events[i].Time = time.Duration(i) * time.Millisecond
+			}
+		}, "apart"},
+		{"slowed down game", func(r *Replay) {
+			r.End *= 3
+			for i := range r.Events {
+				r.Events[i].Time *= 3
+			}
+		}, "frames per second"},
+	}
+	for _, tt := range tests {
+		replay := copyReplay(honest)
+		tt.tamper(replay)
+		v, err := VerifyReplay(replay)
+		if err != nil {
+			t.Fatalf("%s: VerifyReplay() error: %v", tt.name, err)
+		}
+		if v.OK() || !strings.Contains(strings.Join(v.Issues, "\n"), tt.issue) {
+			t.Errorf("%s: expected an issue about %q, got %v", tt.name, tt.issue, v.Issues)
+		}
+	}
+}
+
+func TestVerifyHighScore(t *testing.T) {
+	replay := recordedReplay(t)
+	path := filepath.Join(t.TempDir(), "run.json")
+	if err := replay.Save(path); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	preset, _ := PresetByLevel(replay.Settings.Difficulty)
+	honest := HighScore{Score: replay.Stats.Score, WPM: replay.Stats.WPM, Accuracy: replay.Stats.Accuracy,
+		Words: replay.Stats.WordsTyped, Replay: path, Preset: preset.ID}
+	mode := replay.Settings.Mode
+	v, err := VerifyHighScore(mode, honest)
+	if err != nil {
+		t.Fatalf("VerifyHighScore() error: %v", err)
+	}
+	if !v.OK() {
+		t.Errorf("Expected the entry to verify, got %v", v.Issues)
+	}
+
+	tests := []struct {
+		name  string
+		mode  string
+		edit  func(e *HighScore)
+		issue string
+	}{
+		{"edited score", mode, func(e *HighScore) { e.Score *= 2 }, "score"},
+		{"edited WPM", mode, func(e *HighScore) { e.WPM += 10 }, "WPM"},
+		{"edited accuracy", mode, func(e *HighScore) { e.Accuracy = 100 - e.Accuracy/2 }, "accuracy"},
+		{"other mode", "zen", func(e *HighScore) {}, "table"},
+		{"harder preset", mode, func(e *HighScore) { e.Preset = "insane" }, "Insane"},
+	}
+	for _, tt := range tests {
+		entry := honest
+		tt.edit(&entry)
+		v, err := VerifyHighScore(tt.mode, entry)
+		if err != nil {
+			t.Fatalf("%s: VerifyHighScore() error: %v", tt.name, err)
+		}
+		if v.OK() || !strings.Contains(strings.Join(v.Issues, "\n"), tt.issue) {
+			t.Errorf("%s: expected an issue about %q, got %v", tt.name, tt.issue, v.Issues)
+		}
+	}
+
+	// A replay of an easier tuning doesn't verify under the entry's preset
+	easier := copyReplay(replay)
+	tuning := preset.Tuning
+	tuning.Speed.Start /= 2
+	easier.Tuning = tuning
+	easierPath := filepath.Join(t.TempDir(), "easier.json")
+	if err := easier.Save(easierPath); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	entry := honest
+	entry.Replay = easierPath
+	if v, err := VerifyHighScore(mode, entry); err != nil || !strings.Contains(strings.Join(v.Issues, "\n"), "tuned") {
+		t.Errorf("Expected a replay with an easier tuning to be flagged, got %v (%v)", v, err)
+	}
+
+	// Custom takes any tuning a config file could hold, and nothing beyond it
+	custom := copyReplay(replay)
+	custom.Settings.Difficulty = DifficultyCustom
+	for _, tt := range []struct {
+		start float64
+		ok    bool
+	}{{preset.Tuning.Speed.Start / 2, true}, {0, false}} {
+		tuning := preset.Tuning
+		tuning.Speed.Start = tt.start
+		custom.Tuning = tuning
+		v := &Verification{}
+		v.checkPreset("custom", custom)
+		if v.OK() != tt.ok {
+			t.Errorf("Custom starting at speed %g: expected OK %v, got %v", tt.start, tt.ok, v.Issues)
+		}
+	}
+
+	if _, err := VerifyHighScore(mode, HighScore{Score: 1}); err == nil {
+		t.Error("Expected an error for an entry without a replay")
+	}
+}
@@ -1,63 +1,65 @@
 package core
 
 import (
-	"math/rand"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
 // WordManager handles word selection and management
 type WordManager struct {
-	Words      []string
-	UsedWords  map[string]bool
-	Difficulty int
-	rng        *rand.Rand
+	Words         []string
+	UsedWords     map[string]bool
+	Difficulty    int        // difficulty preset level
+	MinLength     int        // shortest word picked, 0 for any
+	MaxLength     int        // longest word picked, 0 for any
+	Pack          string     // ID of the word pack the words come from
+	PackName      string     // name of that pack, kept as built packs are slow to look up
+	Source        WordSource // makes up words before Words is drawn from, nil for none
+	IgnoreAccents bool       // accented letters match the letter without the accent, e.g. é and e
+	rng           *seededRand
 }
 
-// NewWordManager creates a new word manager
+// NewWordManager creates a new word manager using the default word pack
 func NewWordManager() *WordManager {
-	// Default word list - in a real implementation, this would load from assets/words.txt
-	defaultWords := []string{
-		"the", "and", "for", "are", "but", "not", "you", "all", "can", "her", "was", "one",
-		"our", "had", "day", "get", "use", "man", "new", "now", "way", "may", "say", "each",
-		"which", "their", "time", "will", "about", "would", "there", "could", "other", "after",
-		"first", "never", "these", "think", "where", "being", "every", "great", "might", "shall",
-		"still", "those", "while", "write", "place", "right", "where", "sound", "again", "below",
-		"between", "important", "children", "example", "sentence", "following", "without", "another",
-		"different", "thought", "through", "before", "picture", "country", "together", "followed",
-		"programming", "computer", "keyboard", "function", "variable", "algorithm", "structure",
-		"interface", "development", "framework", "library", "package", "compile", "execute",
+	wm := &WordManager{
+		Words:     wordPacks[0].Words,
+		UsedWords: make(map[string]bool),
+		Pack:      wordPacks[0].ID,
+		PackName:  wordPacks[0].Name,
+		rng:       newSeededRand(time.Now().UnixNano()),
 	}
+	wm.SetDifficulty(DifficultyEasy)
+	return wm
+}
 
-	return &WordManager{
-		Words:      defaultWords,
-		UsedWords:  make(map[string]bool),
-		Difficulty: 1,
-		rng:        rand.New(rand.NewSource(time.Now().UnixNano())),
-	}
+// SetPack switches to the words of the given pack
+func (wm *WordManager) SetPack(pack *WordPack) {
+	wm.Words = pack.Words
+	wm.Source = pack.Source
+	wm.Pack = pack.ID
+	wm.PackName = pack.Name
 }
 
-// GetRandomWord returns a random word based on difficulty
+// Seed reseeds word selection so the same seed yields the same words
+func (wm *WordManager) Seed(seed int64) {
+	wm.rng = newSeededRand(seed)
+}
+
+// GetRandomWord returns a random word within the word length band
 func (wm *WordManager) GetRandomWord() string {
+	if wm.Source != nil {
+		if word, ok := wm.Source.Word(wm.rng, wm.MinLength, wm.MaxLength); ok {
+			return word
+		}
+	}
 	var availableWords []string
 
 	// Filter words based on difficulty
 	for _, word := range wm.Words {
-		wordLen := len(word)
-		switch wm.Difficulty {
-		case 1: // Easy: 3-5 characters
-			if wordLen >= 3 && wordLen <= 5 {
-				availableWords = append(availableWords, word)
-			}
-		case 2: // Medium: 4-8 characters
-			if wordLen >= 4 && wordLen <= 8 {
-				availableWords = append(availableWords, word)
-			}
-		case 3: // Hard: 6+ characters
-			if wordLen >= 6 {
-				availableWords = append(availableWords, word)
-			}
-		default: // All words
+		wordLen := utf8.RuneCountInString(word)
+		if wordLen >= wm.MinLength && (wm.MaxLength == 0 || wordLen <= wm.MaxLength) {
 			availableWords = append(availableWords, word)
 		}
 	}
@@ -71,24 +73,106 @@ func (wm *WordManager) GetRandomWord() string {
 	return strings.ToLower(word)
 }
 
-// SetDifficulty sets the difficulty level (1-3)
+// GetLongWord returns a random word of at least minLength characters,
+// regardless of difficulty. Falls back to a regular word if there is none.
+func (wm *WordManager) GetLongWord(minLength int) string {
+	if wm.Source != nil {
+		if word, ok := wm.Source.Word(wm.rng, minLength, 0); ok {
+			return word
+		}
+	}
+	var longWords []string
+	for _, word := range wm.Words {
+		if utf8.RuneCountInString(word) >= minLength {
+			longWords = append(longWords, word)
+		}
+	}
+	if len(longWords) == 0 {
+		return wm.GetRandomWord()
+	}
+	return strings.ToLower(longWords[wm.rng.Intn(len(longWords))])
+}
+
+// SetDifficulty sets the difficulty level and the word lengths of its preset
 func (wm *WordManager) SetDifficulty(level int) {
-	if level >= 1 && level <= 3 {
+	if preset, ok := PresetByLevel(level); ok {
 		wm.Difficulty = level
+		wm.SetLengths(preset.Tuning.MinWord, preset.Tuning.MaxWord)
 	}
 }
 
+// SetLengths sets the shortest and longest words picked, 0 for any
+func (wm *WordManager) SetLengths(minLength, maxLength int) {
+	wm.MinLength = minLength
+	wm.MaxLength = maxLength
+}
+
 // IsWordComplete checks if a word is completely typed
 func (wm *WordManager) IsWordComplete(word, typed string) bool {
-	return strings.ToLower(word) == strings.ToLower(typed)
+	expected, got := []rune(word), []rune(typed)
+	if len(expected) != len(got) {
+		return false
+	}
+	for i := range expected {
+		if !wm.sameChar(expected[i], got[i]) {
+			return false
+		}
+	}
+	return true
 }
 
 // IsValidChar checks if the next character in typing is valid
 func (wm *WordManager) IsValidChar(word, typed string, char rune) bool {
-	if len(typed) >= len(word) {
-		return false
+	expected := []rune(word)
+	next := utf8.RuneCountInString(typed)
+	return next < len(expected) && wm.sameChar(expected[next], char)
+}
+
+// typedErrors returns which of the typed characters of a word are wrong
+func (wm *WordManager) typedErrors(word, typed string) []bool {
+	expected, got := []rune(word), []rune(typed)
+	wrong := make([]bool, len(got))
+	for i := range got {
+		wrong[i] = i >= len(expected) || !wm.sameChar(expected[i], got[i])
+	}
+	return wrong
+}
+
+// sameChar reports whether typed matches the expected character, ignoring
+// case, and accents if IgnoreAccents is set
+func (wm *WordManager) sameChar(expected, typed rune) bool {
+	expected, typed = unicode.ToLower(expected), unicode.ToLower(typed)
+	if wm.IgnoreAccents {
+		expected, typed = foldAccent(expected), foldAccent(typed)
+	}
+	return expected == typed
+}
+
+// Accent matching choices of the config file
+const (
+	AccentsExact  = "exact"  // accented letters must be typed as they are
+	AccentsIgnore = "ignore" // accented letters match the letter without the accent
+)
+
+// accentFolds maps lowercase accented letters to the letter without the accent
+var accentFolds = func() map[rune]rune {
+	folds := make(map[rune]rune)
+	for base, accented := range map[rune]string{
+		'a': "àáâãäåāăą", 'c': "çćč", 'd': "ď", 'e': "èéêëēėęě", 'g': "ğ", 'i': "ìíîïīį",
+		'l': "ł", 'n': "ñńň", 'o': "òóôõöøōő", 'r': "ř", 's': "śšş", 't': "ť",
+		'u': "ùúûüūůű", 'y': "ýÿ", 'z': "źżž",
+	} {
+		for _, r := range accented {
+			folds[r] = base
+		}
 	}
+	return folds
+}()
 
-	expectedChar := rune(strings.ToLower(word)[len(typed)])
-	return strings.ToLower(string(char)) == string(expectedChar)
+// foldAccent returns r without its accent, r itself if it has none
+func foldAccent(r rune) rune {
+	if base, ok := accentFolds[r]; ok {
+		return base
+	}
+	return r
 }
@@ -1,7 +1,9 @@
 package core
 
 import (
+	"strings"
 	"testing"
+	"unicode"
 )
 
 func TestNewWordManager(t *testing.T) {
@@ -45,7 +47,7 @@ func TestSetDifficulty(t *testing.T) {
 	wm := NewWordManager()
 
 	// Test valid difficulty levels
-	for i := 1; i <= 3; i++ {
+	for i := 1; i <= len(Presets()); i++ {
 		wm.SetDifficulty(i)
 		if wm.Difficulty != i {
 			t.Errorf("Expected difficulty %d, got %d", i, wm.Difficulty)
@@ -59,9 +61,9 @@ func TestSetDifficulty(t *testing.T) {
 		t.Error("SetDifficulty should not accept 0")
 	}
 
-	wm.SetDifficulty(4)
+	wm.SetDifficulty(len(Presets()) + 1)
 	if wm.Difficulty != originalDifficulty {
-		t.Error("SetDifficulty should not accept 4")
+		t.Error("SetDifficulty should not accept a level without a preset")
 	}
 }
 
@@ -80,6 +82,10 @@ func TestIsWordComplete(t *testing.T) {
 		{"test", "test", true},
 		{"test", "tes", false},
 		{"", "", true},
+		{"Müde", "müde", true},   // Case insensitive beyond ASCII
+		{"müde", "mude", false},  // Accents count by default
+		{"café", "caf", false},   // One character short, not one byte
+		{"niño", "niñoo", false}, // Same byte length as the word
 	}
 
 	for _, test := range tests {
@@ -108,6 +114,13 @@ func TestIsValidChar(t *testing.T) {
 		{"HELLO", "", 'h', true},       // Case insensitive
 		{"HELLO", "", 'H', true},       // Case insensitive
 		{"hello", "hello", 'x', false}, // Already complete
+		{"über", "", 'ü', true},
+		{"Über", "", 'ü', true},  // Case insensitive beyond ASCII
+		{"über", "", 'u', false}, // Accents count by default
+		{"grün", "gr", 'ü', true},
+		{"grün", "grü", 'n', true}, // Indexed by character, not byte
+		{"été", "ét", 'é', true},
+		{"été", "été", 'e', false}, // Already complete
 	}
 
 	for _, test := range tests {
@@ -155,3 +168,83 @@ func TestDifficultyFiltering(t *testing.T) {
 		}
 	}
 }
+
+func TestWordPacks(t *testing.T) {
+	packs := WordPacks()
+	if len(packs) < 3 {
+		t.Fatalf("Expected at least 3 bundled word packs, got %d", len(packs))
+	}
+
+	for _, pack := range packs {
+		if len(pack.Words) == 0 {
+			t.Errorf("Word pack %q has no words", pack.ID)
+		}
+		for _, word := range pack.Words {
+			if word == "" || word[0] == '#' {
+				t.Errorf("Word pack %q contains comment or blank entry %q", pack.ID, word)
+			}
+		}
+	}
+
+	wm := NewWordManager()
+	programming, ok := WordPackByID("programming")
+	if !ok {
+		t.Fatal("Expected a programming word pack")
+	}
+	wm.SetPack(programming)
+	if wm.Pack != "programming" || len(wm.Words) != len(programming.Words) {
+		t.Errorf("Expected SetPack to switch to the programming words, got pack %q", wm.Pack)
+	}
+}
+
+func TestIgnoreAccents(t *testing.T) {
+	wm := NewWordManager()
+	wm.IgnoreAccents = true
+	if !wm.IsValidChar("für", "f", 'u') || !wm.IsValidChar("Élève", "", 'e') || !wm.IsValidChar("niño", "ni", 'ñ') {
+		t.Error("Expected accented letters to match the plain ones, and themselves")
+	}
+	if !wm.IsWordComplete("pingüino", "pinguino") || !wm.IsWordComplete("fenêtre", "FENETRE") {
+		t.Error("Expected words typed without accents to be complete")
+	}
+	if wm.IsValidChar("café", "caf", 'a') || wm.IsWordComplete("ça", "sa") {
+		t.Error("Expected other letters to stay wrong")
+	}
+	got := wm.typedErrors("Straßen", "strasx")
+	want := []bool{false, false, false, false, true, true}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("typedErrors() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestLanguagePacks(t *testing.T) {
+	for _, id := range []string{"de", "es", "fr"} {
+		pack, ok := WordPackByID(id)
+		if !ok || pack.Language != id || len(pack.Words) < 50 {
+			t.Fatalf("Expected a bundled %s pack, got %v", id, pack)
+		}
+		accented := 0
+		for _, word := range pack.Words {
+			if strings.IndexFunc(word, func(r rune) bool { return r > unicode.MaxASCII }) >= 0 {
+				accented++
+			}
+			if strings.IndexFunc(word, func(r rune) bool { return !isTypable(r) }) >= 0 {
+				t.Errorf("%s: %q can't be typed", id, word)
+			}
+		}
+		if accented == 0 {
+			t.Errorf("Expected accented words in the %s pack", id)
+		}
+	}
+
+	// Lengths count characters, so "größer" is 6 long
+	wm := NewWordManager()
+	wm.Words = []string{"größer", "öl"}
+	wm.SetLengths(3, 6)
+	for i := 0; i < 10; i++ {
+		if word := wm.GetRandomWord(); word != "größer" {
+			t.Fatalf("Expected only the 6 letter word, got %q", word)
+		}
+	}
+}